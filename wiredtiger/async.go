@@ -0,0 +1,373 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <stdint.h>
+#include <errno.h>
+#include <wiredtiger.h>
+
+typedef struct {
+	WT_ASYNC_CALLBACK iface;
+	uintptr_t handle;
+} GO_ASYNC_CALLBACK;
+
+extern void goAsyncNotify(uintptr_t handle, uint64_t id, int optype, int op_ret, void *key_data, size_t key_size, void *value_data, size_t value_size);
+
+// The callback structure lives for a single operation: WiredTiger recycles
+// the op once notify returns.
+static int wiredtiger_async_notify(WT_ASYNC_CALLBACK *cb, WT_ASYNC_OP *op, int op_ret, uint32_t flags) {
+	WT_ASYNC_OPTYPE type;
+	WT_ITEM key = { 0 }, value = { 0 };
+
+	type = op->get_type(op);
+
+	if (op_ret == 0 && type != WT_AOP_COMPACT && op->get_key(op, &key) != 0)
+		key.size = 0;
+
+	if (op_ret == 0 && type == WT_AOP_SEARCH && op->get_value(op, &value) != 0)
+		value.size = 0;
+
+	goAsyncNotify(((GO_ASYNC_CALLBACK *)cb)->handle, op->get_id(op), type, op_ret, (void *)key.data, key.size, (void *)value.data, value.size);
+	free(cb);
+
+	return 0;
+}
+
+// Reports through the connection's event handler, or stderr without one.
+static void wiredtiger_async_err(WT_CONNECTION *connection, const char *message) {
+	WT_EXTENSION_API *api = connection->get_extension_api(connection);
+
+	(void)api->err_printf(api, NULL, "%s", message);
+}
+
+static int wiredtiger_connection_async_new_op(WT_CONNECTION *connection, const char *uri, const char *config, uintptr_t handle, WT_ASYNC_OP **asyncopp, GO_ASYNC_CALLBACK **cbp) {
+	GO_ASYNC_CALLBACK *cb;
+	int ret;
+
+	if ((cb = calloc(1, sizeof(GO_ASYNC_CALLBACK))) == NULL)
+		return ENOMEM;
+
+	cb->iface.notify = wiredtiger_async_notify;
+	cb->handle = handle;
+
+	if ((ret = connection->async_new_op(connection, uri, config, &cb->iface, asyncopp)) != 0) {
+		free(cb);
+		return ret;
+	}
+
+	*cbp = cb;
+	return 0;
+}
+
+static uint64_t wiredtiger_async_op_get_id(WT_ASYNC_OP *op) {
+	return op->get_id(op);
+}
+
+static int wiredtiger_connection_async_flush(WT_CONNECTION *connection) {
+	return connection->async_flush(connection);
+}
+
+// WiredTiger copies raw keys and values into the op, so the Go buffers need
+// only live for the call.
+static int wiredtiger_async_op_run(WT_ASYNC_OP *op, int optype, const void *key_data, size_t key_size, int key_set, const void *value_data, size_t value_size, int value_set) {
+	WT_ITEM key, value;
+
+	if (key_set) {
+		key.data = key_data;
+		key.size = key_size;
+		op->set_key(op, &key);
+	}
+
+	if (value_set) {
+		value.data = value_data;
+		value.size = value_size;
+		op->set_value(op, &value);
+	}
+
+	switch (optype) {
+	case WT_AOP_COMPACT:
+		return op->compact(op);
+	case WT_AOP_INSERT:
+		return op->insert(op);
+	case WT_AOP_REMOVE:
+		return op->remove(op);
+	case WT_AOP_SEARCH:
+		return op->search(op);
+	case WT_AOP_UPDATE:
+		return op->update(op);
+	}
+
+	return EINVAL;
+}
+*/
+import "C"
+import (
+	"fmt"
+	"runtime/cgo"
+	"unsafe"
+)
+
+type AsyncOpType int
+
+const (
+	AsyncNone    = AsyncOpType(C.WT_AOP_NONE)
+	AsyncCompact = AsyncOpType(C.WT_AOP_COMPACT)
+	AsyncInsert  = AsyncOpType(C.WT_AOP_INSERT)
+	AsyncRemove  = AsyncOpType(C.WT_AOP_REMOVE)
+	AsyncSearch  = AsyncOpType(C.WT_AOP_SEARCH)
+	AsyncUpdate  = AsyncOpType(C.WT_AOP_UPDATE)
+)
+
+// AsyncResult is the outcome of an asynchronous operation. Key holds the
+// packed key of the operation and Value the packed value found by a search;
+// both are copies owned by the result.
+type AsyncResult struct {
+	ID    uint64
+	Type  AsyncOpType
+	Err   error
+	Key   []byte
+	Value []byte
+
+	keyFormat   string
+	valueFormat string
+}
+
+func (r *AsyncResult) GetKey(a ...interface{}) error {
+	return UnPack(nil, r.keyFormat, r.Key, a...)
+}
+
+func (r *AsyncResult) GetValue(a ...interface{}) error {
+	return UnPack(nil, r.valueFormat, r.Value, a...)
+}
+
+// AsyncCallback receives the result of an asynchronous operation. It runs on
+// a WiredTiger worker thread and should return quickly. A panic in the
+// callback is recovered and reported as an error to the connection's event
+// handler.
+type AsyncCallback func(result *AsyncResult)
+
+// AsyncOp is a single asynchronous operation: set its key and value, then
+// start exactly one of Search, Insert, Update, Remove or Compact. An op that
+// is not started must be released with Close. The connection must be opened
+// with "async=(enabled=true)".
+type AsyncOp struct {
+	w           *C.WT_ASYNC_OP
+	cb          *C.GO_ASYNC_CALLBACK
+	handle      cgo.Handle
+	id          uint64
+	keyFormat   string
+	valueFormat string
+	keyPack     []byte
+	keySet      bool
+	valuePack   []byte
+	valueSet    bool
+}
+
+type asyncState struct {
+	conn        *C.WT_CONNECTION
+	callback    AsyncCallback
+	keyFormat   string
+	valueFormat string
+}
+
+// AsyncNewOp allocates an asynchronous operation on uri whose result is
+// passed to callback.
+func (c *Connection) AsyncNewOp(uri, config string, callback AsyncCallback) (*AsyncOp, error) {
+	if callback == nil {
+		return nil, NewError(EINVAL, nil)
+	}
+
+	op := new(AsyncOp)
+	st := &asyncState{conn: c.w, callback: callback}
+	op.handle = cgo.NewHandle(st)
+
+	uriC := C.CString(uri)
+	defer C.free(unsafe.Pointer(uriC))
+
+	// Keys and values cross as packed bytes, like cursors.
+	if len(config) > 0 {
+		config += ","
+	}
+
+	configC := C.CString(config + "raw=true")
+	defer C.free(unsafe.Pointer(configC))
+
+	if res := int(C.wiredtiger_connection_async_new_op(c.w, uriC, configC, C.uintptr_t(op.handle), &op.w, &op.cb)); res != 0 {
+		op.handle.Delete()
+		return nil, NewError(res, nil)
+	}
+
+	op.id = uint64(C.wiredtiger_async_op_get_id(op.w))
+	op.keyFormat = C.GoString(op.w.key_format)
+	op.valueFormat = C.GoString(op.w.value_format)
+	st.keyFormat = op.keyFormat
+	st.valueFormat = op.valueFormat
+
+	return op, nil
+}
+
+// AsyncNewOpChan allocates an asynchronous operation on uri whose result is
+// sent on results. One channel may collect the results of many operations;
+// it must have room for them or be drained promptly, since the send blocks a
+// WiredTiger worker thread.
+func (c *Connection) AsyncNewOpChan(uri, config string, results chan<- *AsyncResult) (*AsyncOp, error) {
+	if results == nil {
+		return nil, NewError(EINVAL, nil)
+	}
+
+	return c.AsyncNewOp(uri, config, func(result *AsyncResult) {
+		results <- result
+	})
+}
+
+// AsyncFlush waits for all queued asynchronous operations to complete.
+func (c *Connection) AsyncFlush() error {
+	if res := int(C.wiredtiger_connection_async_flush(c.w)); res != 0 {
+		return NewError(res, nil)
+	}
+
+	return nil
+}
+
+// GetId returns the identifier WiredTiger assigned to the operation, which
+// is repeated in its AsyncResult.
+func (op *AsyncOp) GetId() uint64 {
+	return op.id
+}
+
+func (op *AsyncOp) GetKeyFormat() string {
+	return op.keyFormat
+}
+
+func (op *AsyncOp) GetValueFormat() string {
+	return op.valueFormat
+}
+
+func (op *AsyncOp) SetKey(a ...interface{}) error {
+	var res error
+	op.keyPack, res = Pack(nil, op.keyFormat, op.keyPack, a...)
+
+	op.keySet = res == nil
+	return res
+}
+
+func (op *AsyncOp) SetValue(a ...interface{}) error {
+	var res error
+	op.valuePack, res = Pack(nil, op.valueFormat, op.valuePack, a...)
+
+	op.valueSet = res == nil
+	return res
+}
+
+func (op *AsyncOp) Search() error {
+	return op.run(AsyncSearch)
+}
+
+func (op *AsyncOp) Insert() error {
+	return op.run(AsyncInsert)
+}
+
+func (op *AsyncOp) Update() error {
+	return op.run(AsyncUpdate)
+}
+
+func (op *AsyncOp) Remove() error {
+	return op.run(AsyncRemove)
+}
+
+func (op *AsyncOp) Compact() error {
+	return op.run(AsyncCompact)
+}
+
+func (op *AsyncOp) run(optype AsyncOpType) error {
+	var keyData, valueData unsafe.Pointer
+	var keySet, valueSet C.int
+
+	if op.w == nil {
+		return NewError(EINVAL, nil)
+	}
+
+	if op.keySet {
+		keySet = 1
+		if len(op.keyPack) > 0 {
+			keyData = unsafe.Pointer(&op.keyPack[0])
+		}
+	}
+
+	if op.valueSet {
+		valueSet = 1
+		if len(op.valuePack) > 0 {
+			valueData = unsafe.Pointer(&op.valuePack[0])
+		}
+	}
+
+	res := int(C.wiredtiger_async_op_run(op.w, C.int(optype), keyData, C.size_t(len(op.keyPack)), keySet, valueData, C.size_t(len(op.valuePack)), valueSet))
+
+	// Once queued the op belongs to WiredTiger, and a failed op is returned
+	// to its free list without notification.
+	cb, h := op.cb, op.handle
+	op.w, op.cb, op.handle = nil, nil, 0
+
+	if res != 0 {
+		C.free(unsafe.Pointer(cb))
+		h.Delete()
+		return NewError(res, nil)
+	}
+
+	return nil
+}
+
+// Close releases an op that was never started, along with its callback.
+// Closing an op that was started, or closing twice, does nothing.
+// WiredTiger has no call to hand back an unused op, so its slot stays
+// taken until the connection is closed.
+func (op *AsyncOp) Close() error {
+	if op.handle == 0 {
+		return nil
+	}
+
+	C.free(unsafe.Pointer(op.cb))
+	op.handle.Delete()
+	op.w, op.cb, op.handle = nil, nil, 0
+
+	return nil
+}
+
+//export goAsyncNotify
+func goAsyncNotify(handle C.uintptr_t, id C.uint64_t, optype C.int, opRet C.int, keyData unsafe.Pointer, keySize C.size_t, valueData unsafe.Pointer, valueSize C.size_t) {
+	h := cgo.Handle(handle)
+	st := h.Value().(*asyncState)
+	h.Delete()
+
+	r := &AsyncResult{
+		ID:          uint64(id),
+		Type:        AsyncOpType(optype),
+		keyFormat:   st.keyFormat,
+		valueFormat: st.valueFormat,
+	}
+
+	if opRet != 0 {
+		r.Err = NewError(int(opRet), nil)
+	}
+
+	if keySize > 0 {
+		r.Key = C.GoBytes(keyData, C.int(keySize))
+	}
+
+	if valueSize > 0 {
+		r.Value = C.GoBytes(valueData, C.int(valueSize))
+	}
+
+	// A panicking callback must not unwind through WiredTiger's worker, and
+	// there is no caller left to return it to.
+	defer func() {
+		if p := recover(); p != nil {
+			messageC := C.CString(fmt.Sprintf("async callback for operation %d panicked: %v", r.ID, p))
+			C.wiredtiger_async_err(st.conn, messageC)
+			C.free(unsafe.Pointer(messageC))
+		}
+	}()
+
+	st.callback(r)
+}
@@ -0,0 +1,190 @@
+package wiredtiger
+
+import (
+	"errors"
+	"os"
+	"runtime/cgo"
+	"strings"
+	"testing"
+)
+
+func TestAsyncOpCloseUnused(t *testing.T) {
+	h := cgo.NewHandle(&asyncState{})
+	op := &AsyncOp{handle: h}
+
+	if err := op.Close(); err != nil {
+		t.Fatalf("Got error while closing op: %v", err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected the handle to be released by Close")
+			}
+		}()
+		h.Value()
+	}()
+
+	if err := op.Close(); err != nil {
+		t.Errorf("Expected a second Close to do nothing, got %v", err)
+	}
+
+	if err := op.Insert(); err == nil {
+		t.Error("Expected an error running a closed op")
+	}
+}
+
+func TestAsyncOpClose(t *testing.T) {
+	os.Mkdir("data_async", 0777)
+	defer os.RemoveAll("data_async")
+
+	con, err := Open("data_async", "create,async=(enabled=true,ops_max=16)")
+	if err != nil {
+		t.Fatalf("Got error while open database: %v", err)
+	}
+
+	defer con.Close("")
+
+	session, err := con.OpenSession("")
+	if err != nil {
+		t.Fatalf("Got error while open session: %v", err)
+	}
+
+	if err = session.Create("table:async", "key_format=S,value_format=S"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	op, err := con.AsyncNewOp("table:async", "", func(*AsyncResult) {})
+	if err != nil {
+		t.Fatalf("Got error while allocating op: %v", err)
+	}
+
+	if err = op.Close(); err != nil {
+		t.Errorf("Got error while closing op: %v", err)
+	}
+
+	if err = op.Insert(); err == nil {
+		t.Error("Expected an error running a closed op")
+	}
+}
+
+func TestAsyncOpChan(t *testing.T) {
+	con, err := Open(t.TempDir(), "create,async=(enabled=true,ops_max=16)")
+	if err != nil {
+		t.Fatalf("Got error while open database: %v", err)
+	}
+
+	defer con.Close("")
+
+	session, err := con.OpenSession("")
+	if err != nil {
+		t.Fatalf("Got error while open session: %v", err)
+	}
+
+	if err = session.Create("table:async", "key_format=S,value_format=S"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	results := make(chan *AsyncResult, 4)
+
+	run := func(optype AsyncOpType, key string, value ...interface{}) *AsyncResult {
+		t.Helper()
+
+		op, err := con.AsyncNewOpChan("table:async", "", results)
+		if err != nil {
+			t.Fatalf("Got error while allocating op: %v", err)
+		}
+
+		op.SetKey(key)
+		if len(value) > 0 {
+			op.SetValue(value...)
+		}
+
+		switch optype {
+		case AsyncInsert:
+			err = op.Insert()
+		case AsyncSearch:
+			err = op.Search()
+		}
+		if err != nil {
+			t.Fatalf("Got error while running op: %v", err)
+		}
+
+		if err = con.AsyncFlush(); err != nil {
+			t.Fatalf("Got error while flush: %v", err)
+		}
+
+		r := <-results
+		if r.ID != op.GetId() || r.Type != optype {
+			t.Errorf("Got result %d of type %v, expected %d of type %v", r.ID, r.Type, op.GetId(), optype)
+		}
+
+		return r
+	}
+
+	if r := run(AsyncInsert, "k", "v"); r.Err != nil {
+		t.Fatalf("Insert returned %v", r.Err)
+	}
+
+	var key, value string
+
+	r := run(AsyncSearch, "k")
+	if r.Err == nil {
+		if err = r.GetKey(&key); err == nil {
+			err = r.GetValue(&value)
+		}
+	} else {
+		err = r.Err
+	}
+
+	if err != nil || key != "k" || value != "v" {
+		t.Errorf("Search returned %q, %q, %v", key, value, err)
+	}
+
+	var e *Error
+	if r = run(AsyncSearch, "missing"); !errors.As(r.Err, &e) || e.Code != WT_NOTFOUND {
+		t.Errorf("Search of a missing key returned %v, expected WT_NOTFOUND", r.Err)
+	}
+}
+
+func TestAsyncOpPanic(t *testing.T) {
+	handler := new(recordingHandler)
+
+	con, err := OpenWithHandler(t.TempDir(), handler, "create,async=(enabled=true,ops_max=16)")
+	if err != nil {
+		t.Fatalf("Got error while open database: %v", err)
+	}
+
+	defer con.Close("")
+
+	session, err := con.OpenSession("")
+	if err != nil {
+		t.Fatalf("Got error while open session: %v", err)
+	}
+
+	if err = session.Create("table:async", "key_format=S,value_format=S"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	op, err := con.AsyncNewOp("table:async", "", func(*AsyncResult) { panic("callback failed") })
+	if err != nil {
+		t.Fatalf("Got error while allocating op: %v", err)
+	}
+
+	op.SetKey("k")
+	op.SetValue("v")
+	if err = op.Insert(); err != nil {
+		t.Fatalf("Got error while running op: %v", err)
+	}
+
+	if err = con.AsyncFlush(); err != nil {
+		t.Fatalf("Got error while flush: %v", err)
+	}
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+
+	if !strings.Contains(strings.Join(handler.errors, "\n"), "callback failed") {
+		t.Errorf("Expected the panic to reach the event handler, got %q", handler.errors)
+	}
+}
@@ -0,0 +1,509 @@
+package wiredtiger
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// BackupOptions configures a hot backup. The zero value backs up the whole
+// database.
+type BackupOptions struct {
+	// Targets limits the backup to these URIs, such as "table:access".
+	Targets []string
+	// ThisID names the backup as the base for block incremental backups,
+	// see BackupBlockIncremental. It needs WiredTiger 10 or later.
+	ThisID string
+}
+
+func (o *BackupOptions) config() string {
+	var config []string
+
+	if o == nil {
+		return ""
+	}
+
+	if len(o.Targets) > 0 {
+		targets := make([]string, len(o.Targets))
+		for i, t := range o.Targets {
+			targets[i] = strconv.Quote(t)
+		}
+
+		config = append(config, "target=("+strings.Join(targets, ",")+")")
+	}
+
+	if len(o.ThisID) > 0 {
+		config = append(config, "incremental=(enabled=true,this_id="+strconv.Quote(o.ThisID)+")")
+	}
+
+	return strings.Join(config, ",")
+}
+
+// backupFiles opens a backup cursor with config and calls fn with it and the
+// list of files to copy. WiredTiger keeps those files consistent only while
+// the cursor is open, so fn must finish copying before it returns.
+func (s *Session) backupFiles(config string, fn func(c *Cursor, names []string) error) error {
+	c, err := s.OpenCursor("backup:", nil, config)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+
+	for _, err := range c.All() {
+		var name string
+
+		if err == nil {
+			err = c.GetKey(&name)
+		}
+
+		if err != nil {
+			c.Close()
+			return err
+		}
+
+		names = append(names, name)
+	}
+
+	if err = fn(c, names); err != nil {
+		c.Close()
+		return err
+	}
+
+	return c.Close()
+}
+
+// Backup copies a consistent snapshot of the database, including its log
+// files, into destDir, creating it if need be. The database stays open for
+// reads and writes meanwhile. Files are read through the operating system,
+// so Backup does not suit connections opened with OpenWithFileSystem.
+func (s *Session) Backup(destDir string, opts *BackupOptions) error {
+	home := s.conn.GetHome()
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return err
+	}
+
+	return s.backupFiles(opts.config(), func(c *Cursor, names []string) error {
+		if err := copyFiles(home, destDir, names); err != nil {
+			return err
+		}
+
+		return syncDir(destDir)
+	})
+}
+
+// BackupTar writes the files Backup would copy to w as a tar stream.
+func (s *Session) BackupTar(w io.Writer, opts *BackupOptions) error {
+	home := s.conn.GetHome()
+
+	return s.backupFiles(opts.config(), func(c *Cursor, names []string) error {
+		tw := tar.NewWriter(w)
+
+		for _, name := range names {
+			if err := tarFile(tw, filepath.Join(home, name), name); err != nil {
+				return err
+			}
+		}
+
+		return tw.Close()
+	})
+}
+
+func copyFiles(srcDir, dstDir string, names []string) error {
+	for _, name := range names {
+		dst := filepath.Join(dstDir, name)
+
+		if err := makeParent(dst); err != nil {
+			return err
+		}
+
+		if err := copyFile(filepath.Join(srcDir, name), dst); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// makeParent creates the directory path goes in: log files may live in a
+// subdirectory of the home.
+func makeParent(path string) error {
+	return os.MkdirAll(filepath.Dir(path), 0755)
+}
+
+func tarFile(tw *tar.Writer, path, name string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	hdr, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = filepath.ToSlash(name)
+
+	if err = tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	// The header fixed the size; a log file growing meanwhile is cut there.
+	_, err = io.CopyN(tw, f, fi.Size())
+	return err
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(out, in); err == nil {
+		err = out.Sync()
+	}
+
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	return d.Sync()
+}
+
+// Incremental backups
+//
+// An incremental backup goes into a directory of its own, next to the full
+// backup it builds on, and records what it holds in a manifest there.
+// RestoreBackup replays a full backup and its increments, in order, into a
+// home directory.
+
+const backupManifestName = "wiredtiger-go.backup"
+
+// Block incremental entry types; older headers do not define them.
+const (
+	backupFile  = 1
+	backupRange = 2
+)
+
+const (
+	backupLog   = "log"
+	backupBlock = "block"
+)
+
+type backupManifest struct {
+	Kind  string
+	Files []backupManifestFile
+}
+
+// Ranges of a block increment sit at their own offsets in a sparse copy of
+// the file; Size is the length of the file once they are applied.
+type backupManifestFile struct {
+	Name   string
+	Full   bool
+	Size   int64
+	Ranges [][2]int64 `json:",omitempty"`
+}
+
+func writeBackupManifest(dir string, m *backupManifest) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, backupManifestName), data, 0644); err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+// BackupLogIncremental copies the log files of the database into destDir.
+// With removeLogs set, log files no longer needed are removed from the
+// database afterwards, so each increment holds only the logs written since
+// the previous one; the database must then be backed up this way alone.
+// Without it nothing is removed, and every call copies all the log files
+// still present again.
+func (s *Session) BackupLogIncremental(destDir string, removeLogs bool) error {
+	home := s.conn.GetHome()
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return err
+	}
+
+	return s.backupFiles(`target=("log:")`, func(c *Cursor, names []string) error {
+		m := &backupManifest{Kind: backupLog}
+
+		for _, name := range names {
+			m.Files = append(m.Files, backupManifestFile{Name: name, Full: true})
+		}
+
+		if err := copyFiles(home, destDir, names); err != nil {
+			return err
+		}
+
+		if err := writeBackupManifest(destDir, m); err != nil {
+			return err
+		}
+
+		if removeLogs {
+			return s.Truncate("log:", c, nil, "")
+		}
+
+		return nil
+	})
+}
+
+// BackupBlockIncremental copies the blocks changed since the backup named
+// srcID into destDir and names this backup thisID, for the next increment
+// to build on. It needs WiredTiger 10 or later and a chain started by a full
+// Backup with BackupOptions.ThisID.
+func (s *Session) BackupBlockIncremental(destDir, srcID, thisID string) error {
+	home := s.conn.GetHome()
+
+	if len(srcID) == 0 || len(thisID) == 0 {
+		return NewError(EINVAL, s)
+	}
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return err
+	}
+
+	config := "incremental=(enabled=true,src_id=" + strconv.Quote(srcID) + ",this_id=" + strconv.Quote(thisID) + ")"
+
+	return s.backupFiles(config, func(c *Cursor, names []string) error {
+		m := &backupManifest{Kind: backupBlock}
+
+		for _, name := range names {
+			f, err := s.backupBlocks(c, home, destDir, name)
+			if err != nil {
+				return err
+			}
+
+			m.Files = append(m.Files, f)
+		}
+
+		return writeBackupManifest(destDir, m)
+	})
+}
+
+// backupBlocks copies the changes of one file, as listed by a duplicate of
+// the incremental backup cursor.
+func (s *Session) backupBlocks(backup *Cursor, home, destDir, name string) (backupManifestFile, error) {
+	f := backupManifestFile{Name: name}
+
+	c, err := s.OpenCursor("", backup, "incremental=(file="+strconv.Quote(name)+")")
+	if err != nil {
+		return f, err
+	}
+	defer c.Close()
+
+	src, err := os.Open(filepath.Join(home, name))
+	if err != nil {
+		return f, err
+	}
+	defer src.Close()
+
+	path := filepath.Join(destDir, name)
+	if err = makeParent(path); err != nil {
+		return f, err
+	}
+
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return f, err
+	}
+	defer dst.Close()
+
+	for _, err := range c.All() {
+		var offset, size int64
+		var typ uint32
+
+		if err == nil {
+			err = c.GetKey(&offset, &size, &typ)
+		}
+
+		if err != nil {
+			return f, err
+		}
+
+		switch typ {
+		case backupFile:
+			f.Full = true
+			f.Ranges = nil
+			if _, err = src.Seek(0, io.SeekStart); err == nil {
+				_, err = io.Copy(dst, src)
+			}
+		case backupRange:
+			f.Ranges = append(f.Ranges, [2]int64{offset, size})
+			_, err = io.CopyN(io.NewOffsetWriter(dst, offset), io.NewSectionReader(src, offset, size), size)
+		default:
+			err = NewError(EINVAL, s)
+		}
+
+		if err != nil {
+			return f, err
+		}
+
+		if f.Full {
+			break
+		}
+	}
+
+	fi, err := src.Stat()
+	if err != nil {
+		return f, err
+	}
+	f.Size = fi.Size()
+
+	return f, dst.Sync()
+}
+
+// RestoreBackup reassembles a home directory from a full backup followed by
+// its increments, oldest first. The home directory is created if need be and
+// should not hold a database.
+func RestoreBackup(home string, full string, increments ...string) error {
+	if err := os.MkdirAll(home, 0755); err != nil {
+		return err
+	}
+
+	var names []string
+
+	// Log files may sit in a subdirectory.
+	err := filepath.WalkDir(full, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(full, path)
+		if err == nil && rel != backupManifestName {
+			names = append(names, rel)
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = copyFiles(full, home, names); err != nil {
+		return err
+	}
+
+	for _, dir := range increments {
+		if err = restoreIncrement(home, dir); err != nil {
+			return err
+		}
+	}
+
+	return syncDir(home)
+}
+
+func restoreIncrement(home, dir string) error {
+	var m backupManifest
+
+	data, err := os.ReadFile(filepath.Join(dir, backupManifestName))
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool)
+
+	for _, f := range m.Files {
+		keep[f.Name] = true
+
+		if f.Full {
+			if err = copyFiles(dir, home, []string{f.Name}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err = applyRanges(filepath.Join(dir, f.Name), filepath.Join(home, f.Name), f); err != nil {
+			return err
+		}
+	}
+
+	if m.Kind != backupBlock {
+		return nil
+	}
+
+	// A block increment lists every file of the database, log files in
+	// subdirectories included: the rest were dropped since the previous
+	// backup.
+	return filepath.WalkDir(home, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(home, path)
+		if err == nil && !keep[rel] {
+			err = os.Remove(path)
+		}
+
+		return err
+	})
+}
+
+func applyRanges(src, dst string, f backupManifestFile) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range f.Ranges {
+		if _, err = io.CopyN(io.NewOffsetWriter(out, r[0]), io.NewSectionReader(in, r[0], r[1]), r[1]); err != nil {
+			break
+		}
+	}
+
+	if err == nil {
+		err = out.Truncate(f.Size)
+	}
+
+	if err == nil {
+		err = out.Sync()
+	}
+
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
@@ -0,0 +1,179 @@
+package wiredtiger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path string, data []byte) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	root := t.TempDir()
+	full := filepath.Join(root, "full")
+	logs := filepath.Join(root, "incr1")
+	blocks := filepath.Join(root, "incr2")
+	home := filepath.Join(root, "home")
+
+	writeTestFile(t, filepath.Join(full, "WiredTiger"), []byte("version"))
+	writeTestFile(t, filepath.Join(full, "access.wt"), []byte("0123456789"))
+	writeTestFile(t, filepath.Join(full, "dropped.wt"), []byte("gone"))
+	writeTestFile(t, filepath.Join(full, "journal", "WiredTigerLog.0000000001"), []byte("log1"))
+
+	writeTestFile(t, filepath.Join(logs, "journal", "WiredTigerLog.0000000002"), []byte("log2"))
+	err := writeBackupManifest(logs, &backupManifest{Kind: backupLog, Files: []backupManifestFile{
+		{Name: filepath.Join("journal", "WiredTigerLog.0000000002"), Full: true},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bytes 2-3 and 12-13 changed and the file grew to 14 bytes.
+	writeTestFile(t, filepath.Join(blocks, "access.wt"), []byte("\x00\x00AB\x00\x00\x00\x00\x00\x00\x00\x00CD"))
+	writeTestFile(t, filepath.Join(blocks, "WiredTiger"), []byte("version2"))
+	writeTestFile(t, filepath.Join(blocks, "journal", "WiredTigerLog.0000000002"), []byte("log2"))
+	err = writeBackupManifest(blocks, &backupManifest{Kind: backupBlock, Files: []backupManifestFile{
+		{Name: "WiredTiger", Full: true},
+		{Name: "access.wt", Size: 14, Ranges: [][2]int64{{2, 2}, {12, 2}}},
+		{Name: filepath.Join("journal", "WiredTigerLog.0000000002"), Full: true},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = RestoreBackup(home, full, logs, blocks); err != nil {
+		t.Fatalf("Got error while restoring: %v", err)
+	}
+
+	for name, expected := range map[string]string{
+		"WiredTiger":                       "version2",
+		"access.wt":                        "01AB456789\x00\x00CD",
+		"journal/WiredTigerLog.0000000002": "log2",
+	} {
+		data, err := os.ReadFile(filepath.Join(home, name))
+		if err != nil {
+			t.Errorf("Restored file %s: %v", name, err)
+		} else if string(data) != expected {
+			t.Errorf("Restored file %s holds %q, expected %q", name, data, expected)
+		}
+	}
+
+	for _, name := range []string{"dropped.wt", "journal/WiredTigerLog.0000000001"} {
+		if _, err = os.Stat(filepath.Join(home, name)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s, missing from the block increment, to be removed, got %v", name, err)
+		}
+	}
+
+	if _, err = os.Stat(filepath.Join(home, backupManifestName)); !os.IsNotExist(err) {
+		t.Errorf("Expected the manifest not to be restored, got %v", err)
+	}
+}
+
+func TestBackupRestoreOpen(t *testing.T) {
+	root := t.TempDir()
+	home := filepath.Join(root, "home")
+	dest := filepath.Join(root, "backup")
+	restored := filepath.Join(root, "restored")
+
+	if err := os.Mkdir(home, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := Open(home, "create,log=(enabled=true)")
+	if err != nil {
+		t.Fatalf("Got error while open database: %v", err)
+	}
+	defer conn.Close("")
+
+	session, err := conn.OpenSession("")
+	if err != nil {
+		t.Fatalf("Got error while open session: %v", err)
+	}
+
+	if err = session.Create("table:backup", "key_format=S,value_format=S"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	c, err := session.OpenCursor("table:backup", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+
+	for _, k := range []string{"a", "b", "c"} {
+		c.SetKey(k)
+		c.SetValue("value " + k)
+		if err = c.Insert(); err != nil {
+			t.Fatalf("Got error while insert: %v", err)
+		}
+	}
+	c.Close()
+
+	if err = session.Backup(dest, nil); err != nil {
+		t.Fatalf("Got error while backup: %v", err)
+	}
+
+	// Written after the backup, so missing from the restored copy.
+	c, err = session.OpenCursor("table:backup", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+	c.SetKey("d")
+	c.SetValue("value d")
+	if err = c.Insert(); err != nil {
+		t.Fatalf("Got error while insert: %v", err)
+	}
+	c.Close()
+
+	if err = RestoreBackup(restored, dest); err != nil {
+		t.Fatalf("Got error while restoring: %v", err)
+	}
+
+	rconn, err := Open(restored, "log=(enabled=true)")
+	if err != nil {
+		t.Fatalf("Got error while open restored database: %v", err)
+	}
+	defer rconn.Close("")
+
+	rsession, err := rconn.OpenSession("")
+	if err != nil {
+		t.Fatalf("Got error while open session: %v", err)
+	}
+
+	rc, err := rsession.OpenCursor("table:backup", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open restored table: %v", err)
+	}
+	defer rc.Close()
+
+	var keys []string
+	for c, err := range rc.All() {
+		var k, v string
+
+		if err == nil {
+			err = c.GetKey(&k)
+		}
+		if err == nil {
+			err = c.GetValue(&v)
+		}
+		if err != nil {
+			t.Fatalf("Got error while reading restored table: %v", err)
+		}
+
+		if v != "value "+k {
+			t.Errorf("Restored record %q holds %q", k, v)
+		}
+		keys = append(keys, k)
+	}
+
+	if len(keys) != 3 || keys[0] != "a" || keys[2] != "c" {
+		t.Errorf("Restored keys %q, expected a, b and c", keys)
+	}
+}
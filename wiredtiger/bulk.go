@@ -0,0 +1,214 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <wiredtiger.h>
+
+// Rows are packed back to back in data; sizes holds the key and value size
+// of each in turn. done is set to the number of rows inserted.
+static int wiredtiger_cursor_insert_batch(WT_CURSOR *cursor, const char *data, const size_t *sizes, int nrows, int *done) {
+	WT_ITEM key, value;
+	int i, ret;
+
+	for (i = 0; i < nrows; i++, sizes += 2) {
+		key.data = data;
+		key.size = sizes[0];
+		data += sizes[0];
+
+		value.data = data;
+		value.size = sizes[1];
+		data += sizes[1];
+
+		cursor->set_key(cursor, &key);
+		cursor->set_value(cursor, &value);
+
+		if ((ret = cursor->insert(cursor)) != 0) {
+			*done = i;
+			return ret;
+		}
+	}
+
+	*done = nrows;
+	return 0;
+}
+*/
+import "C"
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"unsafe"
+)
+
+// BulkOptions configures a bulk load. The zero value is usable.
+type BulkOptions struct {
+	// BatchSize is the number of rows passed to WiredTiger at once;
+	// 1000 when zero.
+	BatchSize int
+	// Progress, if set, is called with the number of rows loaded so far
+	// after every batch.
+	Progress func(rows uint64)
+}
+
+// BulkOrderError reports a key that does not follow the previous one in
+// a bulk load. Row counts from 1; keys are packed.
+type BulkOrderError struct {
+	Row  uint64
+	Key  []byte
+	Prev []byte
+}
+
+func (e *BulkOrderError) Error() string {
+	return fmt.Sprintf("wiredtiger: bulk load row %d: key % x is not greater than the previous key % x", e.Row, e.Key, e.Prev)
+}
+
+// BulkLoader fills a new, empty object through a bulk cursor. Keys must be
+// strictly ascending, compared byte by byte in packed form, which matches
+// the table's order unless it uses a custom collator.
+type BulkLoader struct {
+	session   *Session
+	cursor    *Cursor
+	ctx       context.Context
+	opts      BulkOptions
+	keyPack   []byte
+	valuePack []byte
+	last      []byte
+	data      []byte
+	sizes     []C.size_t
+	batched   int
+	rows      uint64
+	err       error
+}
+
+// BulkLoad opens a bulk cursor on uri, which must name a newly created
+// object. opts may be nil.
+func (s *Session) BulkLoad(uri string, opts *BulkOptions) (*BulkLoader, error) {
+	return s.BulkLoadContext(context.Background(), uri, opts)
+}
+
+// BulkLoadContext is BulkLoad bound to ctx: once ctx is done no further
+// batch is passed to WiredTiger, and the loader fails with the context's
+// error. Close must still be called.
+func (s *Session) BulkLoadContext(ctx context.Context, uri string, opts *BulkOptions) (*BulkLoader, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b := &BulkLoader{session: s, ctx: ctx}
+
+	if opts != nil {
+		b.opts = *opts
+	}
+
+	if b.opts.BatchSize < 0 {
+		return nil, NewError(EINVAL, s)
+	} else if b.opts.BatchSize == 0 {
+		b.opts.BatchSize = 1000
+	}
+
+	c, err := s.OpenCursor(uri, nil, "bulk=true")
+	if err != nil {
+		return nil, err
+	}
+
+	b.cursor = c
+	return b, nil
+}
+
+func (b *BulkLoader) SetKey(a ...interface{}) error {
+	var res error
+	b.keyPack, res = Pack(b.session, b.cursor.keyFormat, b.keyPack, a...)
+	return res
+}
+
+func (b *BulkLoader) SetValue(a ...interface{}) error {
+	var res error
+	b.valuePack, res = Pack(b.session, b.cursor.valueFormat, b.valuePack, a...)
+	return res
+}
+
+// Insert adds the row set with SetKey and SetValue.
+func (b *BulkLoader) Insert() error {
+	return b.InsertRaw(b.keyPack, b.valuePack)
+}
+
+// InsertRaw adds a row from an already packed key and value.
+func (b *BulkLoader) InsertRaw(key, value []byte) error {
+	if b.err != nil {
+		return b.err
+	}
+
+	row := b.rows + uint64(b.batched) + 1
+
+	if b.last != nil && bytes.Compare(key, b.last) <= 0 {
+		return &BulkOrderError{Row: row, Key: append([]byte{}, key...), Prev: append([]byte{}, b.last...)}
+	}
+
+	b.last = append(b.last[:0], key...)
+	b.data = append(b.data, key...)
+	b.data = append(b.data, value...)
+	b.sizes = append(b.sizes, C.size_t(len(key)), C.size_t(len(value)))
+	b.batched++
+
+	if b.batched >= b.opts.BatchSize {
+		return b.Flush()
+	}
+
+	return nil
+}
+
+// Rows returns the number of rows passed to WiredTiger so far.
+func (b *BulkLoader) Rows() uint64 {
+	return b.rows
+}
+
+// Flush passes the pending rows to WiredTiger.
+func (b *BulkLoader) Flush() error {
+	var data unsafe.Pointer
+	var done C.int
+
+	if b.err != nil || b.batched == 0 {
+		return b.err
+	}
+
+	if err := b.ctx.Err(); err != nil {
+		b.err = err
+		return err
+	}
+
+	if len(b.data) > 0 {
+		data = unsafe.Pointer(&b.data[0])
+	}
+
+	res := int(C.wiredtiger_cursor_insert_batch(b.cursor.w, (*C.char)(data), &b.sizes[0], C.int(b.batched), &done))
+	b.rows += uint64(done)
+
+	b.data = b.data[:0]
+	b.sizes = b.sizes[:0]
+	b.batched = 0
+
+	// A bulk cursor cannot carry on after a failed insert.
+	if res != 0 {
+		b.err = NewError(res, b.session)
+		return b.err
+	}
+
+	if b.opts.Progress != nil {
+		b.opts.Progress(b.rows)
+	}
+
+	return nil
+}
+
+// Close flushes the pending rows and closes the bulk cursor, which completes
+// the load.
+func (b *BulkLoader) Close() error {
+	err := b.Flush()
+
+	if cerr := b.cursor.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
@@ -0,0 +1,34 @@
+package wiredtiger
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBulkLoaderOrder(t *testing.T) {
+	// A batch that never fills keeps WiredTiger out of the test.
+	b := &BulkLoader{opts: BulkOptions{BatchSize: 100}}
+
+	for _, k := range []int64{-5, 0, 7, 300} {
+		key, _ := Pack(nil, "q", nil, k)
+		if err := b.InsertRaw(key, []byte{1}); err != nil {
+			t.Fatalf("Got error inserting ascending key %d: %v", k, err)
+		}
+	}
+
+	key, _ := Pack(nil, "q", nil, int64(7))
+	err := b.InsertRaw(key, []byte{1})
+
+	var oerr *BulkOrderError
+	if !errors.As(err, &oerr) {
+		t.Fatalf("Expected a BulkOrderError, got %v", err)
+	}
+
+	if oerr.Row != 5 {
+		t.Errorf("Expected the error on row 5, got %d", oerr.Row)
+	}
+
+	if b.batched != 4 {
+		t.Errorf("Expected the rejected row not to be batched, have %d rows", b.batched)
+	}
+}
@@ -0,0 +1,110 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <stdint.h>
+#include <errno.h>
+#include <wiredtiger.h>
+
+typedef struct {
+	WT_COLLATOR iface;
+	uintptr_t handle;
+} GO_COLLATOR;
+
+extern int goCollatorCompare(uintptr_t handle, void *k1, size_t k1_size, void *k2, size_t k2_size, int *cmp);
+extern void goCollatorTerminate(uintptr_t handle);
+
+static int wiredtiger_collator_compare(WT_COLLATOR *collator, WT_SESSION *session, const WT_ITEM *key1, const WT_ITEM *key2, int *cmp) {
+	return goCollatorCompare(((GO_COLLATOR *)collator)->handle, (void *)key1->data, key1->size, (void *)key2->data, key2->size, cmp);
+}
+
+static int wiredtiger_collator_terminate(WT_COLLATOR *collator, WT_SESSION *session) {
+	goCollatorTerminate(((GO_COLLATOR *)collator)->handle);
+	free(collator);
+
+	return 0;
+}
+
+static int wiredtiger_connection_add_collator(WT_CONNECTION *connection, const char *name, uintptr_t handle, const char *config) {
+	GO_COLLATOR *c;
+	int ret;
+
+	if ((c = calloc(1, sizeof(GO_COLLATOR))) == NULL)
+		return ENOMEM;
+
+	c->iface.compare = wiredtiger_collator_compare;
+	c->iface.terminate = wiredtiger_collator_terminate;
+	c->handle = handle;
+
+	if ((ret = connection->add_collator(connection, name, &c->iface, config)) != 0)
+		free(c);
+
+	return ret;
+}
+*/
+import "C"
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+// Collator orders the keys of tables and indexes created with
+// "collator=name". Compare returns a negative number, zero or a positive
+// number when a sorts before, equal to or after b.
+//
+// The keys are the packed key bytes and are only valid during the call.
+// Compare is called concurrently from WiredTiger's threads.
+type Collator interface {
+	Compare(a, b []byte) int
+}
+
+func (c *Connection) AddCollator(name string, collator Collator) error {
+	var nameC *C.char = nil
+
+	if collator == nil {
+		return NewError(EINVAL, nil)
+	}
+
+	if len(name) > 0 {
+		nameC = C.CString(name)
+		defer C.free(unsafe.Pointer(nameC))
+	}
+
+	h := cgo.NewHandle(collator)
+
+	if res := int(C.wiredtiger_connection_add_collator(c.w, nameC, C.uintptr_t(h), nil)); res != 0 {
+		h.Delete()
+		return NewError(res, nil)
+	}
+
+	return nil
+}
+
+//export goCollatorCompare
+func goCollatorCompare(handle C.uintptr_t, k1 unsafe.Pointer, k1Size C.size_t, k2 unsafe.Pointer, k2Size C.size_t, cmp *C.int) (res C.int) {
+	defer callbackRecover(&res)
+
+	c := cgo.Handle(handle).Value().(Collator)
+	*cmp = C.int(collatorResult(c.Compare(borrowBytes(k1, k1Size), borrowBytes(k2, k2Size))))
+
+	return 0
+}
+
+// collatorResult narrows a Compare result to -1, 0 or 1, since a C int
+// cannot hold every Go int.
+func collatorResult(r int) int {
+	switch {
+	case r < 0:
+		return -1
+	case r > 0:
+		return 1
+	}
+
+	return 0
+}
+
+//export goCollatorTerminate
+func goCollatorTerminate(handle C.uintptr_t) {
+	cgo.Handle(handle).Delete()
+}
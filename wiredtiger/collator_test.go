@@ -0,0 +1,83 @@
+package wiredtiger
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCollatorResult(t *testing.T) {
+	for _, tc := range []struct {
+		r, expected int
+	}{
+		{0, 0},
+		{1, 1},
+		{-1, -1},
+		{42, 1},
+		{-42, -1},
+		{1 << 32, 1},
+		{-1 << 32, -1},
+		{1<<32 + 1<<31, 1},
+		{math.MaxInt, 1},
+		{math.MinInt, -1},
+	} {
+		if r := collatorResult(tc.r); r != tc.expected {
+			t.Errorf("collatorResult(%d) = %d, expected %d", tc.r, r, tc.expected)
+		}
+	}
+}
+
+// reverseCollator orders keys backwards, returning results that do not fit
+// in 32 bits.
+type reverseCollator struct{}
+
+func (reverseCollator) Compare(a, b []byte) int {
+	return bytes.Compare(b, a) << 32
+}
+
+func TestCollator(t *testing.T) {
+	conn, session := openTestSession(t, "")
+
+	if err := conn.AddCollator("reverse", reverseCollator{}); err != nil {
+		t.Fatalf("Got error while add collator: %v", err)
+	}
+
+	if err := session.Create("table:reverse", "key_format=S,value_format=S,collator=reverse"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	c, err := session.OpenCursor("table:reverse", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+	defer c.Close()
+
+	for _, k := range []string{"b", "a", "c"} {
+		c.SetKey(k)
+		c.SetValue(k)
+		if err = c.Insert(); err != nil {
+			t.Fatalf("Got error while insert: %v", err)
+		}
+	}
+
+	if err = c.Reset(); err != nil {
+		t.Fatalf("Got error while reset: %v", err)
+	}
+
+	var keys []string
+
+	for c.Next() == nil {
+		var k string
+
+		if err = c.GetKey(&k); err != nil {
+			t.Fatalf("Got error while get key: %v", err)
+		}
+
+		keys = append(keys, k)
+	}
+
+	if got := strings.Join(keys, ","); got != "c,b,a" {
+		t.Errorf("Got keys %q, expected %q", got, "c,b,a")
+	}
+}
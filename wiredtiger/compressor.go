@@ -0,0 +1,227 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <stdint.h>
+#include <errno.h>
+#include <wiredtiger.h>
+
+typedef struct {
+	WT_COMPRESSOR iface;
+	uintptr_t handle;
+} GO_COMPRESSOR;
+
+extern int goCompressorCompress(uintptr_t handle, void *src, size_t src_len, void *dst, size_t dst_len, size_t *result_lenp, int *compression_failed);
+extern int goCompressorDecompress(uintptr_t handle, void *src, size_t src_len, void *dst, size_t dst_len, size_t *result_lenp);
+extern int goCompressorPreSize(uintptr_t handle, void *src, size_t src_len, size_t *result_lenp);
+extern void goCompressorTerminate(uintptr_t handle);
+
+static int wiredtiger_compressor_compress(WT_COMPRESSOR *compressor, WT_SESSION *session, uint8_t *src, size_t src_len, uint8_t *dst, size_t dst_len, size_t *result_lenp, int *compression_failed) {
+	return goCompressorCompress(((GO_COMPRESSOR *)compressor)->handle, src, src_len, dst, dst_len, result_lenp, compression_failed);
+}
+
+static int wiredtiger_compressor_decompress(WT_COMPRESSOR *compressor, WT_SESSION *session, uint8_t *src, size_t src_len, uint8_t *dst, size_t dst_len, size_t *result_lenp) {
+	return goCompressorDecompress(((GO_COMPRESSOR *)compressor)->handle, src, src_len, dst, dst_len, result_lenp);
+}
+
+static int wiredtiger_compressor_pre_size(WT_COMPRESSOR *compressor, WT_SESSION *session, uint8_t *src, size_t src_len, size_t *result_lenp) {
+	return goCompressorPreSize(((GO_COMPRESSOR *)compressor)->handle, src, src_len, result_lenp);
+}
+
+static int wiredtiger_compressor_terminate(WT_COMPRESSOR *compressor, WT_SESSION *session) {
+	goCompressorTerminate(((GO_COMPRESSOR *)compressor)->handle);
+	free(compressor);
+
+	return 0;
+}
+
+static int wiredtiger_connection_add_compressor(WT_CONNECTION *connection, const char *name, uintptr_t handle, const char *config) {
+	GO_COMPRESSOR *c;
+	int ret;
+
+	if ((c = calloc(1, sizeof(GO_COMPRESSOR))) == NULL)
+		return ENOMEM;
+
+	c->iface.compress = wiredtiger_compressor_compress;
+	c->iface.decompress = wiredtiger_compressor_decompress;
+	c->iface.pre_size = wiredtiger_compressor_pre_size;
+	c->iface.terminate = wiredtiger_compressor_terminate;
+	c->handle = handle;
+
+	if ((ret = connection->add_compressor(connection, name, &c->iface, config)) != 0)
+		free(c);
+
+	return ret;
+}
+*/
+import "C"
+import (
+	"runtime/cgo"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Compressor compresses the blocks of tables created with
+// "block_compressor=name" and, if configured, the log.
+//
+// Compress writes the compressed form of src into dst and returns its
+// length. Returning 0 with a nil error tells WiredTiger the block does not
+// compress, and it is written as is. Decompress writes the original block
+// into dst, which is sized to hold it, and returns its length. PreSize
+// returns the size of the dst buffer Compress needs for src.
+//
+// src and dst are only valid during the call. The methods are called
+// concurrently from WiredTiger's threads.
+type Compressor interface {
+	Compress(dst, src []byte) (int, error)
+	Decompress(dst, src []byte) (int, error)
+	PreSize(src []byte) int
+}
+
+// CompressorStats counts the work done by a compressor added with
+// AddCompressor since the connection was opened. The counts cover every
+// table using the compressor, as WiredTiger does not tell a compressor
+// which table a block belongs to; to measure tables apart, add the same
+// codec under a different name for each of them.
+type CompressorStats struct {
+	Compressed      uint64 // blocks compressed
+	Failed          uint64 // blocks that did not compress
+	BytesIn         uint64 // bytes of the compressed blocks before compression
+	BytesOut        uint64 // bytes of the compressed blocks after compression
+	Decompressed    uint64 // blocks decompressed
+	DecompressBytes uint64 // bytes produced by decompression
+}
+
+// Ratio returns BytesIn/BytesOut, or 0 if nothing was compressed.
+func (s CompressorStats) Ratio() float64 {
+	if s.BytesOut == 0 {
+		return 0
+	}
+
+	return float64(s.BytesIn) / float64(s.BytesOut)
+}
+
+type goCompressor struct {
+	c               Compressor
+	compressed      uint64
+	failed          uint64
+	bytesIn         uint64
+	bytesOut        uint64
+	decompressed    uint64
+	decompressBytes uint64
+}
+
+func (c *Connection) AddCompressor(name string, compressor Compressor) error {
+	var nameC *C.char = nil
+
+	if compressor == nil {
+		return NewError(EINVAL, nil)
+	}
+
+	if len(name) > 0 {
+		nameC = C.CString(name)
+		defer C.free(unsafe.Pointer(nameC))
+	}
+
+	gc := &goCompressor{c: compressor}
+	h := cgo.NewHandle(gc)
+
+	if res := int(C.wiredtiger_connection_add_compressor(c.w, nameC, C.uintptr_t(h), nil)); res != 0 {
+		h.Delete()
+		return NewError(res, nil)
+	}
+
+	c.mu.Lock()
+	if c.compressors == nil {
+		c.compressors = make(map[string]*goCompressor)
+	}
+	c.compressors[name] = gc
+	c.mu.Unlock()
+
+	return nil
+}
+
+// CompressorStats returns the counters of the compressor added under name,
+// summed over all the tables using it.
+func (c *Connection) CompressorStats(name string) (CompressorStats, bool) {
+	c.mu.Lock()
+	gc, ok := c.compressors[name]
+	c.mu.Unlock()
+
+	if !ok {
+		return CompressorStats{}, false
+	}
+
+	return CompressorStats{
+		Compressed:      atomic.LoadUint64(&gc.compressed),
+		Failed:          atomic.LoadUint64(&gc.failed),
+		BytesIn:         atomic.LoadUint64(&gc.bytesIn),
+		BytesOut:        atomic.LoadUint64(&gc.bytesOut),
+		Decompressed:    atomic.LoadUint64(&gc.decompressed),
+		DecompressBytes: atomic.LoadUint64(&gc.decompressBytes),
+	}, true
+}
+
+//export goCompressorCompress
+func goCompressorCompress(handle C.uintptr_t, src unsafe.Pointer, srcLen C.size_t, dst unsafe.Pointer, dstLen C.size_t, resultLen *C.size_t, failed *C.int) (res C.int) {
+	defer callbackRecover(&res)
+
+	gc := cgo.Handle(handle).Value().(*goCompressor)
+
+	n, err := gc.c.Compress(borrowBytes(dst, dstLen), borrowBytes(src, srcLen))
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	if n <= 0 || n > int(dstLen) {
+		atomic.AddUint64(&gc.failed, 1)
+		*failed = 1
+		return 0
+	}
+
+	atomic.AddUint64(&gc.compressed, 1)
+	atomic.AddUint64(&gc.bytesIn, uint64(srcLen))
+	atomic.AddUint64(&gc.bytesOut, uint64(n))
+
+	*failed = 0
+	*resultLen = C.size_t(n)
+	return 0
+}
+
+//export goCompressorDecompress
+func goCompressorDecompress(handle C.uintptr_t, src unsafe.Pointer, srcLen C.size_t, dst unsafe.Pointer, dstLen C.size_t, resultLen *C.size_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	gc := cgo.Handle(handle).Value().(*goCompressor)
+
+	n, err := gc.c.Decompress(borrowBytes(dst, dstLen), borrowBytes(src, srcLen))
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	if n < 0 || n > int(dstLen) {
+		return C.int(WT_ERROR)
+	}
+
+	atomic.AddUint64(&gc.decompressed, 1)
+	atomic.AddUint64(&gc.decompressBytes, uint64(n))
+
+	*resultLen = C.size_t(n)
+	return 0
+}
+
+//export goCompressorPreSize
+func goCompressorPreSize(handle C.uintptr_t, src unsafe.Pointer, srcLen C.size_t, resultLen *C.size_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	gc := cgo.Handle(handle).Value().(*goCompressor)
+	*resultLen = C.size_t(gc.c.PreSize(borrowBytes(src, srcLen)))
+
+	return 0
+}
+
+//export goCompressorTerminate
+func goCompressorTerminate(handle C.uintptr_t) {
+	cgo.Handle(handle).Delete()
+}
@@ -0,0 +1,126 @@
+package wiredtiger
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Compressors implemented in Go, for use with Connection.AddCompressor.
+// Other codecs can be added by implementing Compressor.
+
+var errShortBuffer = errors.New("wiredtiger: compressed data does not fit the buffer")
+
+// fixedWriter writes into a preallocated buffer and fails once it is full.
+type fixedWriter struct {
+	b []byte
+	n int
+}
+
+func (w *fixedWriter) Write(p []byte) (int, error) {
+	if len(p) > len(w.b)-w.n {
+		return 0, errShortBuffer
+	}
+
+	w.n += copy(w.b[w.n:], p)
+	return len(p), nil
+}
+
+// resetWriter is a compressing writer that can be reused, as flate.Writer
+// and zlib.Writer are.
+type resetWriter interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// streamCompressor adapts a streaming codec from the standard library.
+type streamCompressor struct {
+	newWriter func(w io.Writer) (resetWriter, error)
+	newReader func(r io.Reader) (io.ReadCloser, error)
+	writers   sync.Pool
+}
+
+// NewFlateCompressor returns a DEFLATE compressor using the given
+// compress/flate level.
+func NewFlateCompressor(level int) (Compressor, error) {
+	if _, err := flate.NewWriter(io.Discard, level); err != nil {
+		return nil, NewError(EINVAL, nil)
+	}
+
+	return &streamCompressor{
+		newWriter: func(w io.Writer) (resetWriter, error) { return flate.NewWriter(w, level) },
+		newReader: func(r io.Reader) (io.ReadCloser, error) { return flate.NewReader(r), nil },
+	}, nil
+}
+
+// NewZlibCompressor returns a compressor producing the zlib format, as
+// WiredTiger's zlib extension does, using the given compress/zlib level.
+func NewZlibCompressor(level int) (Compressor, error) {
+	if _, err := zlib.NewWriterLevel(io.Discard, level); err != nil {
+		return nil, NewError(EINVAL, nil)
+	}
+
+	return &streamCompressor{
+		newWriter: func(w io.Writer) (resetWriter, error) { return zlib.NewWriterLevel(w, level) },
+		newReader: zlib.NewReader,
+	}, nil
+}
+
+func (c *streamCompressor) Compress(dst, src []byte) (int, error) {
+	var err error
+
+	fw := &fixedWriter{b: dst}
+
+	w, _ := c.writers.Get().(resetWriter)
+	if w == nil {
+		if w, err = c.newWriter(fw); err != nil {
+			return 0, err
+		}
+	} else {
+		w.Reset(fw)
+	}
+	defer c.writers.Put(w)
+
+	if _, err = w.Write(src); err != nil {
+		if err == errShortBuffer {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	if err = w.Close(); err != nil {
+		if err == errShortBuffer {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	// Not worth keeping unless it saves space.
+	if fw.n >= len(src) {
+		return 0, nil
+	}
+
+	return fw.n, nil
+}
+
+func (c *streamCompressor) Decompress(dst, src []byte) (int, error) {
+	r, err := c.newReader(bytes.NewReader(src))
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	n, err := io.ReadFull(r, dst)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return n, err
+	}
+
+	return n, nil
+}
+
+func (c *streamCompressor) PreSize(src []byte) int {
+	return len(src)
+}
@@ -0,0 +1,63 @@
+package wiredtiger
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"math/rand"
+	"testing"
+)
+
+func TestCompressors(t *testing.T) {
+	text := bytes.Repeat([]byte("WiredTiger compresses repetitive pages well. "), 200)
+
+	noise := make([]byte, 4096)
+	rand.New(rand.NewSource(1)).Read(noise)
+
+	for _, tc := range []struct {
+		name string
+		new  func(level int) (Compressor, error)
+		best int
+	}{
+		{"flate", NewFlateCompressor, flate.BestCompression},
+		{"zlib", NewZlibCompressor, zlib.BestCompression},
+	} {
+		if _, err := tc.new(tc.best + 1); err == nil {
+			t.Errorf("%s: expected an error for an invalid level", tc.name)
+		}
+
+		c, err := tc.new(tc.best)
+		if err != nil {
+			t.Fatalf("%s: got error while create compressor: %v", tc.name, err)
+		}
+
+		// Twice, the second time with a pooled writer.
+		for i := 0; i < 2; i++ {
+			dst := make([]byte, c.PreSize(text))
+
+			n, err := c.Compress(dst, text)
+			if err != nil || n == 0 || n >= len(text) {
+				t.Fatalf("%s: compress returned %d, %v", tc.name, n, err)
+			}
+
+			out := make([]byte, len(text))
+
+			m, err := c.Decompress(out, dst[:n])
+			if err != nil || m != len(text) || !bytes.Equal(out, text) {
+				t.Fatalf("%s: decompress returned %d, %v", tc.name, m, err)
+			}
+		}
+
+		if n, err := c.Compress(make([]byte, c.PreSize(noise)), noise); err != nil || n != 0 {
+			t.Errorf("%s: compress of random data returned %d, %v, expected 0", tc.name, n, err)
+		}
+
+		if n, err := c.Compress(make([]byte, 8), text); err != nil || n != 0 {
+			t.Errorf("%s: compress into a short buffer returned %d, %v, expected 0", tc.name, n, err)
+		}
+
+		if _, err := c.Decompress(make([]byte, len(text)), noise); err == nil {
+			t.Errorf("%s: expected an error while decompress random data", tc.name)
+		}
+	}
+}
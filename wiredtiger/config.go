@@ -0,0 +1,175 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <wiredtiger.h>
+
+int wiredtiger_config_parser_next(WT_CONFIG_PARSER *parser, WT_CONFIG_ITEM *key, WT_CONFIG_ITEM *value) {
+	return parser->next(parser, key, value);
+}
+
+int wiredtiger_config_parser_close(WT_CONFIG_PARSER *parser) {
+	return parser->close(parser);
+}
+*/
+import "C"
+import (
+	"strings"
+	"unsafe"
+)
+
+type ConfigType int
+
+const (
+	ConfigString ConfigType = iota
+	ConfigBool
+	ConfigID
+	ConfigNum
+	ConfigStruct
+	ConfigList
+)
+
+// ConfigItem is a parsed configuration value. Structs "(...)" and lists
+// "[...]" hold their entries in order; the elements of a list are the keys
+// of its entries.
+type ConfigItem struct {
+	Type    ConfigType
+	Str     string // text of the value, unquoted, brackets included
+	Val     int64  // value of booleans (0 or 1) and numbers
+	entries []ConfigEntry
+}
+
+type ConfigEntry struct {
+	Key   string
+	Value *ConfigItem
+}
+
+// ParseConfig parses a configuration string such as "key_format=S,log=(enabled)".
+// The result is a ConfigStruct item holding the top-level keys.
+func ParseConfig(config string) (*ConfigItem, error) {
+	item := &ConfigItem{Type: ConfigStruct, Str: config}
+
+	if err := item.parse(); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+func (item *ConfigItem) parse() error {
+	var parser *C.WT_CONFIG_PARSER
+	var k, v C.WT_CONFIG_ITEM
+
+	s := item.Str
+	if len(s) > 1 && (s[0] == '(' && s[len(s)-1] == ')' || s[0] == '[' && s[len(s)-1] == ']') {
+		s = s[1 : len(s)-1]
+	}
+
+	if len(strings.TrimSpace(s)) == 0 {
+		return nil
+	}
+
+	configC := C.CString(s)
+	defer C.free(unsafe.Pointer(configC))
+
+	if res := int(C.wiredtiger_config_parser_open(nil, configC, C.size_t(len(s)), &parser)); res != 0 {
+		return NewError(res, nil)
+	}
+	defer C.wiredtiger_config_parser_close(parser)
+
+	for {
+		res := int(C.wiredtiger_config_parser_next(parser, &k, &v))
+		if res == WT_NOTFOUND {
+			return nil
+		} else if res != 0 {
+			return NewError(res, nil)
+		}
+
+		value, err := newConfigItem(&v)
+		if err != nil {
+			return err
+		}
+
+		item.entries = append(item.entries, ConfigEntry{C.GoStringN(k.str, C.int(k.len)), value})
+	}
+}
+
+func newConfigItem(v *C.WT_CONFIG_ITEM) (*ConfigItem, error) {
+	item := &ConfigItem{Str: C.GoStringN(v.str, C.int(v.len)), Val: int64(v.val)}
+
+	switch v._type {
+	case C.WT_CONFIG_ITEM_STRING:
+		item.Type = ConfigString
+	case C.WT_CONFIG_ITEM_BOOL:
+		item.Type = ConfigBool
+	case C.WT_CONFIG_ITEM_ID:
+		item.Type = ConfigID
+	case C.WT_CONFIG_ITEM_NUM:
+		item.Type = ConfigNum
+	case C.WT_CONFIG_ITEM_STRUCT:
+		item.Type = ConfigStruct
+		if strings.HasPrefix(item.Str, "[") {
+			item.Type = ConfigList
+		}
+
+		if err := item.parse(); err != nil {
+			return nil, err
+		}
+	}
+
+	return item, nil
+}
+
+// Get returns the value of key, which may name a nested key with dots, as in
+// "log.enabled". It returns an error with code WT_NOTFOUND if there is none.
+func (item *ConfigItem) Get(key string) (*ConfigItem, error) {
+	cur := item
+
+	for _, k := range strings.Split(key, ".") {
+		var next *ConfigItem
+
+		// As in WiredTiger, the last occurrence of a key wins.
+		for _, e := range cur.entries {
+			if e.Key == k {
+				next = e.Value
+			}
+		}
+
+		if next == nil {
+			return nil, NewError(WT_NOTFOUND, nil)
+		}
+
+		cur = next
+	}
+
+	return cur, nil
+}
+
+// Entries returns the entries of a struct or list in order.
+func (item *ConfigItem) Entries() []ConfigEntry {
+	return item.entries
+}
+
+// Keys returns the keys of a struct, or the elements of a list, in order.
+func (item *ConfigItem) Keys() []string {
+	keys := make([]string, 0, len(item.entries))
+
+	for _, e := range item.entries {
+		keys = append(keys, e.Key)
+	}
+
+	return keys
+}
+
+func (item *ConfigItem) Bool() bool {
+	return item.Val != 0
+}
+
+func (item *ConfigItem) Int() int64 {
+	return item.Val
+}
+
+func (item *ConfigItem) String() string {
+	return item.Str
+}
@@ -0,0 +1,153 @@
+package wiredtiger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func parseTestConfig(t *testing.T, config string) *ConfigItem {
+	t.Helper()
+
+	item, err := ParseConfig(config)
+	if err != nil {
+		t.Fatalf("Got error while parse %q: %v", config, err)
+	}
+
+	return item
+}
+
+func TestParseConfig(t *testing.T) {
+	config := `key_format=S,create,overwrite=false,name="a,b",cache_size=1GB,level=-5,` +
+		`log=(enabled=true,path="journal",file=(max=2MB)),verbose=[api,checkpoint],empty=()`
+
+	item := parseTestConfig(t, config)
+
+	if item.Type != ConfigStruct || item.String() != config {
+		t.Errorf("Got %v %q for the top level, expected the struct", item.Type, item.String())
+	}
+
+	if keys := strings.Join(item.Keys(), ","); keys != "key_format,create,overwrite,name,cache_size,level,log,verbose,empty" {
+		t.Errorf("Got keys %q", keys)
+	}
+
+	for _, tc := range []struct {
+		key string
+		typ ConfigType
+		str string
+		val int64
+	}{
+		{"key_format", ConfigID, "S", 0},
+		{"create", ConfigBool, "", 1},
+		{"overwrite", ConfigBool, "false", 0},
+		{"name", ConfigString, "a,b", 0},
+		{"cache_size", ConfigNum, "1GB", 1 << 30},
+		{"level", ConfigNum, "-5", -5},
+		{"log", ConfigStruct, `(enabled=true,path="journal",file=(max=2MB))`, 0},
+		{"log.enabled", ConfigBool, "true", 1},
+		{"log.path", ConfigString, "journal", 0},
+		{"log.file.max", ConfigNum, "2MB", 2 << 20},
+		{"verbose", ConfigList, "[api,checkpoint]", 0},
+		{"empty", ConfigStruct, "()", 0},
+	} {
+		v, err := item.Get(tc.key)
+		if err != nil {
+			t.Errorf("%s: got error %v", tc.key, err)
+			continue
+		}
+
+		if v.Type != tc.typ || v.Val != tc.val || (tc.key != "create" && v.Str != tc.str) {
+			t.Errorf("%s: got %v %q %d, expected %v %q %d", tc.key, v.Type, v.Str, v.Val, tc.typ, tc.str, tc.val)
+		}
+	}
+
+	if v, _ := item.Get("overwrite"); v.Bool() {
+		t.Error("Expected overwrite=false to be false")
+	}
+
+	if v, _ := item.Get("log.file.max"); v.Int() != 2<<20 {
+		t.Errorf("Got %d for log.file.max", v.Int())
+	}
+
+	verbose, _ := item.Get("verbose")
+	if keys := strings.Join(verbose.Keys(), ","); keys != "api,checkpoint" {
+		t.Errorf("Got list elements %q", keys)
+	}
+
+	log, _ := item.Get("log")
+	entries := log.Entries()
+	if len(entries) != 3 || entries[0].Key != "enabled" || entries[2].Key != "file" || entries[2].Value.Type != ConfigStruct {
+		t.Errorf("Got log entries %+v", entries)
+	}
+
+	if empty, _ := item.Get("empty"); len(empty.Entries()) != 0 {
+		t.Errorf("Got entries %+v for an empty struct", empty.Entries())
+	}
+
+	for _, key := range []string{"missing", "log.missing", "key_format.S", "log.enabled.x"} {
+		var e *Error
+
+		if _, err := item.Get(key); !errors.As(err, &e) || e.Code != WT_NOTFOUND {
+			t.Errorf("%s: got %v, expected WT_NOTFOUND", key, err)
+		}
+	}
+}
+
+func TestParseConfigEdges(t *testing.T) {
+	if item := parseTestConfig(t, ""); len(item.Entries()) != 0 {
+		t.Errorf("Got entries %+v for an empty config", item.Entries())
+	}
+
+	// As in WiredTiger, the last occurrence of a key wins.
+	if v, err := parseTestConfig(t, "a=1,a=2").Get("a"); err != nil || v.Int() != 2 {
+		t.Errorf("Got %v, %v for a repeated key", v, err)
+	}
+
+	for _, bad := range []string{"a=(", "a=[b", `a="b`} {
+		if _, err := ParseConfig(bad); err == nil {
+			t.Errorf("Expected an error while parse %q", bad)
+		}
+	}
+}
+
+func TestExtensionConfigGetItem(t *testing.T) {
+	var list, num *ConfigItem
+	var missing error
+
+	registerTestExtension(t, "test-config", func(conn *Connection, config *ExtensionConfig) error {
+		var err error
+
+		if list, err = config.GetItem("nested.list"); err != nil {
+			return err
+		}
+		if num, err = config.GetItem("nested.size"); err != nil {
+			return err
+		}
+
+		_, missing = config.GetItem("missing")
+		return nil
+	})
+
+	conn, _ := openTestSession(t, "")
+
+	if err := conn.LoadGoExtension("test-config", "nested=(list=[x,y,z],size=4KB)"); err != nil {
+		t.Fatalf("Got error while load extension: %v", err)
+	}
+
+	if list == nil || list.Type != ConfigList || strings.Join(list.Keys(), ",") != "x,y,z" {
+		t.Errorf("Got list %+v", list)
+	}
+
+	if num == nil || num.Type != ConfigNum || num.Int() != 4096 {
+		t.Errorf("Got number %+v", num)
+	}
+
+	if missing == nil {
+		t.Error("Expected an error for a missing key")
+	}
+
+	var nilConfig *ExtensionConfig
+	if _, err := nilConfig.GetItem("a"); err == nil {
+		t.Error("Expected an error from a nil config")
+	}
+}
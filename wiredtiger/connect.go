@@ -29,12 +29,27 @@ int wiredtiger_connection_is_new(WT_CONNECTION *connection) {
 int wiredtiger_connection_open_session(WT_CONNECTION *connection, WT_EVENT_HANDLER *errhandler,	const char *config, WT_SESSION **sessionp) {
 	return connection->open_session(connection, errhandler, config, sessionp);
 }
+
+int wiredtiger_connection_load_extension(WT_CONNECTION *connection, const char *path, const char *config) {
+	return connection->load_extension(connection, path, config);
+}
 */
 import "C"
-import "unsafe"
+import (
+	"sync"
+	"unsafe"
+)
 
 type Connection struct {
-	w *C.WT_CONNECTION
+	w       *C.WT_CONNECTION
+	handler *eventHandler
+	// opening marks a wrapper made by an extension while wiredtiger_open
+	// had not yet returned.
+	opening bool
+
+	mu          sync.Mutex
+	compressors map[string]*goCompressor
+	txnStats    txnCounters
 }
 
 // General
@@ -47,7 +62,10 @@ func (c *Connection) Close(config string) error {
 	}
 
 	if res := int(C.wiredtiger_connection_close(c.w, configC)); res == 0 {
+		unregisterConnection(c.w)
 		c.w = nil
+		c.handler.free()
+		c.handler = nil
 		return nil
 	} else {
 		return NewError(res, nil)
@@ -122,6 +140,12 @@ func (c *Connection) IsNew() bool {
 
 // Session handles
 func (c *Connection) OpenSession(config string) (*Session, error) {
+	return c.OpenSessionWithHandler(nil, config)
+}
+
+// OpenSessionWithHandler opens a session whose events go to handler instead
+// of the connection's event handler.
+func (c *Connection) OpenSessionWithHandler(handler EventHandler, config string) (*Session, error) {
 	var w *C.WT_SESSION
 	var configC *C.char = nil
 
@@ -130,15 +154,41 @@ func (c *Connection) OpenSession(config string) (*Session, error) {
 		defer C.free(unsafe.Pointer(configC))
 	}
 
-	if res := int(C.wiredtiger_connection_open_session(c.w, nil, configC, &w)); res != 0 {
+	h := newEventHandler(handler)
+
+	if res := int(C.wiredtiger_connection_open_session(c.w, h.iface(), configC, &w)); res != 0 {
+		h.free()
 		return nil, NewError(res, nil)
 	}
 
 	newsession := new(Session)
 	newsession.w = w
 	newsession.conn = c
+	newsession.handler = h
+	registerSession(newsession)
 
 	return newsession, nil
 }
 
-// TODO: Extension
+// Extensions
+
+func (c *Connection) LoadExtension(path, config string) error {
+	var pathC *C.char = nil
+	var configC *C.char = nil
+
+	if len(path) > 0 {
+		pathC = C.CString(path)
+		defer C.free(unsafe.Pointer(pathC))
+	}
+
+	if len(config) > 0 {
+		configC = C.CString(config)
+		defer C.free(unsafe.Pointer(configC))
+	}
+
+	if res := int(C.wiredtiger_connection_load_extension(c.w, pathC, configC)); res != 0 {
+		return NewError(res, nil)
+	}
+
+	return nil
+}
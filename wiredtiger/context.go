@@ -0,0 +1,143 @@
+package wiredtiger
+
+import (
+	"context"
+	"iter"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Context variants
+//
+// WiredTiger cannot be interrupted in the middle of a call, so these check
+// the context before each step they take and give up with its error once it
+// is done. Where the engine has a timeout of its own the context's deadline
+// is passed on to it as well, and an engine timeout is then reported as
+// context.DeadlineExceeded.
+
+// operationTimeout reports whether the engine takes operation_timeout_ms,
+// which came with WiredTiger 10; older releases reject the key.
+var operationTimeout = sync.OnceValue(func() bool {
+	_, major, _, _ := Version()
+	return major >= 10
+})
+
+// deadlineConfig appends key=value to config, with value the time left
+// before the context's deadline in units of unit, rounded up. It returns
+// config unchanged for a context without a deadline.
+func deadlineConfig(ctx context.Context, config, key string, unit time.Duration) (string, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return config, nil
+	}
+
+	left := time.Until(deadline)
+	if left <= 0 {
+		return "", context.DeadlineExceeded
+	}
+
+	if len(config) > 0 {
+		config += ","
+	}
+
+	return config + key + "=" + strconv.FormatInt(int64((left+unit-1)/unit), 10), nil
+}
+
+// contextErr prefers the context's error over err once the context is done,
+// since the failure is then most likely down to the cancellation.
+func contextErr(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
+
+// CompactContext is Compact bounded by ctx. The deadline becomes the
+// compaction's timeout, in whole seconds.
+func (s *Session) CompactContext(ctx context.Context, name, config string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	config, err := deadlineConfig(ctx, config, "timeout", time.Second)
+	if err != nil {
+		return err
+	}
+
+	return contextErr(ctx, s.Compact(name, config))
+}
+
+// VerifyContext verifies each of names in turn, stopping at the first
+// failure or once ctx is done. ctx is only checked between objects: the
+// verification of one object runs to completion once started.
+func (s *Session) VerifyContext(ctx context.Context, config string, names ...string) error {
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := s.Verify(name, config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SalvageContext salvages each of names in turn, stopping at the first
+// failure or once ctx is done. ctx is only checked between objects: the
+// salvage of one object runs to completion once started.
+func (s *Session) SalvageContext(ctx context.Context, config string, names ...string) error {
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := s.Salvage(name, config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckpointContext is Checkpoint, not started once ctx is done.
+func (s *Session) CheckpointContext(ctx context.Context, config string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.Checkpoint(config)
+}
+
+// WithContext stops seq once ctx is done, yielding the context's error
+// before the next record. It suits any of the cursor iterators:
+//
+//	for c, err := range WithContext(r.Context(), cursor.All()) {
+//		...
+//	}
+func WithContext[T any](ctx context.Context, seq iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		if err := ctx.Err(); err != nil {
+			yield(zero, err)
+			return
+		}
+
+		for v, err := range seq {
+			if err == nil {
+				if cerr := ctx.Err(); cerr != nil {
+					yield(zero, cerr)
+					return
+				}
+			}
+
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package wiredtiger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDeadlineConfig(t *testing.T) {
+	config, err := deadlineConfig(context.Background(), "verbose=[]", "timeout", time.Second)
+	if err != nil || config != "verbose=[]" {
+		t.Errorf("Unexpected config %q, error %v without a deadline", config, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+
+	if config, err = deadlineConfig(ctx, "", "timeout", time.Second); err != nil || config != "timeout=2" {
+		t.Errorf("Unexpected config %q, error %v", config, err)
+	}
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	if _, err = deadlineConfig(ctx, "", "timeout", time.Second); err != context.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded for a past deadline, got %v", err)
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	seq := func(yield func(int, error) bool) {
+		for i := 0; i < 10; i++ {
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+
+	var got []int
+	var err error
+
+	for v, verr := range WithContext(ctx, seq) {
+		if verr != nil {
+			err = verr
+			break
+		}
+
+		got = append(got, v)
+		if v == 2 {
+			cancel()
+		}
+	}
+
+	if len(got) != 3 || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected 3 values and context.Canceled, got %v and %v", got, err)
+	}
+
+	for _, verr := range WithContext(ctx, seq) {
+		if !errors.Is(verr, context.Canceled) {
+			t.Errorf("Expected context.Canceled before the first value, got %v", verr)
+		}
+	}
+}
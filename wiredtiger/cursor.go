@@ -3,6 +3,7 @@ package wiredtiger
 /*
 #cgo LDFLAGS: -lwiredtiger
 #include <stdlib.h>
+#include <errno.h>
 #include <wiredtiger.h>
 
 #define WT_SIZE_ZERO (size_t)((size_t)(SIZE_MAX) >> 1)
@@ -123,6 +124,36 @@ int wiredtiger_cursor_remove(WT_CURSOR *cursor, const void *data, size_t size) {
 
 	return cursor->remove(cursor);
 }
+
+// The replacement data of all entries is concatenated in data, and desc holds
+// the data size, offset and size of each entry in turn.
+int wiredtiger_cursor_modify(WT_CURSOR *cursor, const void *key_data, size_t key_size, const char *data, const size_t *desc, int nentries) {
+	WT_MODIFY *entries;
+	int i, ret;
+
+	if (key_size != 0) {
+		WT_ITEM key;
+		key.data = key_data;
+		key.size = key_size == WT_SIZE_ZERO ? 0 : key_size;
+		cursor->set_key(cursor, &key);
+	}
+
+	if ((entries = calloc(nentries, sizeof(WT_MODIFY))) == NULL)
+		return ENOMEM;
+
+	for (i = 0; i < nentries; i++, desc += 3) {
+		entries[i].data.data = data;
+		entries[i].data.size = desc[0];
+		entries[i].offset = desc[1];
+		entries[i].size = desc[2];
+		data += desc[0];
+	}
+
+	ret = cursor->modify(cursor, entries, nentries);
+	free(entries);
+
+	return ret;
+}
 */
 import "C"
 import "unsafe"
@@ -139,6 +170,8 @@ type Cursor struct {
 	keySetExt   bool
 	valuePack   []byte
 	valueSetExt bool
+	modifyData  []byte
+	modifyDesc  []C.size_t
 }
 
 // General
@@ -185,18 +218,41 @@ func (c *Cursor) GetValueFormat() string {
 // Data access
 // TODO: implement
 
-func (c *Cursor) GetKey(a ...interface{}) error {
+// rawKey returns the packed key, which WiredTiger owns and may reuse once the
+// cursor moves.
+func (c *Cursor) rawKey() ([]byte, error) {
 	var v C.WT_ITEM
 
 	if res := int(C.wiredtiger_cursor_get_key(c.w, &v)); res != 0 {
-		return NewError(res, c.session)
+		return nil, NewError(res, c.session)
+	}
+
+	return borrowBytes(unsafe.Pointer(v.data), v.size), nil
+}
+
+// rawValue returns the packed value, which WiredTiger owns and may reuse once
+// the cursor moves.
+func (c *Cursor) rawValue() ([]byte, error) {
+	var v C.WT_ITEM
+
+	if res := int(C.wiredtiger_cursor_get_value(c.w, &v)); res != 0 {
+		return nil, NewError(res, c.session)
+	}
+
+	return borrowBytes(unsafe.Pointer(v.data), v.size), nil
+}
+
+func (c *Cursor) GetKey(a ...interface{}) error {
+	d, err := c.rawKey()
+	if err != nil {
+		return err
 	}
 
 	// Fast patch
 	if c.keyFormat == "u" {
 		if len(a) == 1 {
 			if arg, ok := a[0].(*[]byte); ok {
-				*arg = C.GoBytes(unsafe.Pointer(v.data), C.int(v.size))
+				*arg = append([]byte{}, d...)
 				return nil
 			}
 		}
@@ -204,23 +260,20 @@ func (c *Cursor) GetKey(a ...interface{}) error {
 		return NewError(EINVAL, c.session)
 	}
 
-	d := (*[1 << 30]byte)(unsafe.Pointer(v.data))[:int(v.size):int(v.size)]
-
 	return UnPack(c.session, c.keyFormat, d, a...)
 }
 
 func (c *Cursor) GetValue(a ...interface{}) error {
-	var v C.WT_ITEM
-
-	if res := int(C.wiredtiger_cursor_get_value(c.w, &v)); res != 0 {
-		return NewError(res, c.session)
+	d, err := c.rawValue()
+	if err != nil {
+		return err
 	}
 
 	// Fast patch
 	if c.valueFormat == "u" {
 		if len(a) == 1 {
 			if arg, ok := a[0].(*[]byte); ok {
-				*arg = C.GoBytes(unsafe.Pointer(v.data), C.int(v.size))
+				*arg = append([]byte{}, d...)
 				return nil
 			}
 		}
@@ -228,12 +281,43 @@ func (c *Cursor) GetValue(a ...interface{}) error {
 		return NewError(EINVAL, c.session)
 	}
 
-	d := (*[1 << 30]byte)(unsafe.Pointer(v.data))[:int(v.size):int(v.size)]
-
-	// C.GoBytes(unsafe.Pointer(v.data), C.int(v.size))
 	return UnPack(c.session, c.valueFormat, d, a...)
 }
 
+// KeyBytes returns the packed key without copying it. The slice belongs to
+// WiredTiger and is only valid until the next operation on the cursor.
+func (c *Cursor) KeyBytes() ([]byte, error) {
+	return c.rawKey()
+}
+
+// ValueBytes returns the packed value without copying it. The slice belongs
+// to WiredTiger and is only valid until the next operation on the cursor.
+func (c *Cursor) ValueBytes() ([]byte, error) {
+	return c.rawValue()
+}
+
+// GetKeyAlias is GetKey unpacking with UnPackAlias: byte slices it sets are
+// only valid until the next operation on the cursor.
+func (c *Cursor) GetKeyAlias(a ...interface{}) error {
+	d, err := c.rawKey()
+	if err != nil {
+		return err
+	}
+
+	return UnPackAlias(c.session, c.keyFormat, d, a...)
+}
+
+// GetValueAlias is GetValue unpacking with UnPackAlias: byte slices it sets
+// are only valid until the next operation on the cursor.
+func (c *Cursor) GetValueAlias(a ...interface{}) error {
+	d, err := c.rawValue()
+	if err != nil {
+		return err
+	}
+
+	return UnPackAlias(c.session, c.valueFormat, d, a...)
+}
+
 func (c *Cursor) SetKey(a ...interface{}) error {
 	var res error
 	c.keyPack, res = Pack(c.session, c.keyFormat, c.keyPack, a...)
@@ -504,3 +588,76 @@ func (c *Cursor) Remove() error {
 
 	return nil
 }
+
+// Modification replaces Size bytes at Offset of a value with Data. Data may
+// be longer or shorter than Size, growing or shrinking the value; Offset
+// past the end of the value pads it with zero bytes.
+type Modification struct {
+	Data   []byte
+	Offset int
+	Size   int
+}
+
+// packModifications lays entries out for wiredtiger_cursor_modify: their
+// data back to back in c.modifyData, and a data size, offset and size
+// triple for each in c.modifyDesc. It reports false for an empty list or a
+// negative offset or size.
+func (c *Cursor) packModifications(entries []Modification) bool {
+	c.modifyData = c.modifyData[:0]
+	c.modifyDesc = c.modifyDesc[:0]
+
+	if len(entries) == 0 {
+		return false
+	}
+
+	for _, m := range entries {
+		if m.Offset < 0 || m.Size < 0 {
+			return false
+		}
+
+		c.modifyData = append(c.modifyData, m.Data...)
+		c.modifyDesc = append(c.modifyDesc, C.size_t(len(m.Data)), C.size_t(m.Offset), C.size_t(m.Size))
+	}
+
+	return true
+}
+
+// Modify applies modifications to the value of the record with the current
+// key, sending only the changed bytes to WiredTiger and its log. The value
+// format must be "u", and the cursor must be in an explicit transaction
+// with snapshot isolation.
+func (c *Cursor) Modify(entries []Modification) error {
+	var key_data, data unsafe.Pointer
+	var key_size C.size_t
+
+	if !c.packModifications(entries) {
+		return NewError(EINVAL, c.session)
+	}
+
+	if len(c.keyPack) > 0 {
+		key_data = unsafe.Pointer(&c.keyPack[0])
+		key_size = C.size_t(len(c.keyPack))
+	} else if c.keySetExt {
+		key_size = C.WT_SIZE_ZERO
+	}
+
+	if len(c.modifyData) > 0 {
+		data = unsafe.Pointer(&c.modifyData[0])
+	}
+
+	if res := int(C.wiredtiger_cursor_modify(c.w, key_data, key_size, (*C.char)(data), &c.modifyDesc[0], C.int(len(entries)))); res != 0 {
+		return NewError(res, c.session)
+	}
+
+	if c.keySetExt {
+		c.keyPack = c.keyPack[:0]
+		c.keySetExt = false
+	}
+
+	if c.valueSetExt {
+		c.valuePack = c.valuePack[:0]
+		c.valueSetExt = false
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package wiredtiger
+
+import (
+	"testing"
+)
+
+func TestPackModifications(t *testing.T) {
+	c := new(Cursor)
+
+	ok := c.packModifications([]Modification{
+		{Data: []byte("AB"), Offset: 2, Size: 2},
+		{Data: []byte("xyz"), Offset: 10, Size: 0},
+		{Data: nil, Offset: 20, Size: 4},
+		{Data: []byte("Q"), Offset: 30, Size: 1},
+	})
+	if !ok {
+		t.Fatal("Expected modifications to pack")
+	}
+
+	if string(c.modifyData) != "ABxyzQ" {
+		t.Errorf("Unexpected data %q", c.modifyData)
+	}
+
+	expected := []uint64{2, 2, 2, 3, 10, 0, 0, 20, 4, 1, 30, 1}
+	if len(c.modifyDesc) != len(expected) {
+		t.Fatalf("Unexpected descriptors %v, expected %v", c.modifyDesc, expected)
+	}
+
+	for i, v := range expected {
+		if uint64(c.modifyDesc[i]) != v {
+			t.Fatalf("Unexpected descriptors %v, expected %v", c.modifyDesc, expected)
+		}
+	}
+
+	// A single zero-size insert, reusing the buffers.
+	if !c.packModifications([]Modification{{Data: []byte("ins"), Offset: 5}}) {
+		t.Fatal("Expected a zero-size insert to pack")
+	}
+
+	if string(c.modifyData) != "ins" || len(c.modifyDesc) != 3 ||
+		uint64(c.modifyDesc[0]) != 3 || uint64(c.modifyDesc[1]) != 5 || uint64(c.modifyDesc[2]) != 0 {
+		t.Errorf("Unexpected insert %q, %v", c.modifyData, c.modifyDesc)
+	}
+
+	for _, bad := range [][]Modification{nil, {}, {{Offset: -1}}, {{Size: -1}}} {
+		if c.packModifications(bad) {
+			t.Errorf("Expected %v to be rejected", bad)
+		}
+	}
+}
+
+func TestCursorModify(t *testing.T) {
+	_, session := openTestSession(t, "")
+
+	if err := session.Create("table:modify", "key_format=S,value_format=u"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	c, err := session.OpenCursor("table:modify", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+	defer c.Close()
+
+	c.SetKey("k")
+	c.SetValue([]byte("0123456789"))
+	if err = c.Insert(); err != nil {
+		t.Fatalf("Got error while insert: %v", err)
+	}
+
+	if err = session.BeginTransaction("isolation=snapshot"); err != nil {
+		t.Fatalf("Got error while begin transaction: %v", err)
+	}
+
+	c.SetKey("k")
+	if err = c.Modify([]Modification{
+		{Data: []byte("AB"), Offset: 1, Size: 2},
+		{Data: []byte("-"), Offset: 5, Size: 0},
+		{Data: []byte("Z"), Offset: 9, Size: 1},
+	}); err != nil {
+		t.Fatalf("Got error while modify: %v", err)
+	}
+
+	c.SetKey("k")
+	if err = c.Modify(nil); err == nil {
+		t.Error("Expected an error for an empty modification list")
+	}
+
+	if err = session.CommitTransaction(""); err != nil {
+		t.Fatalf("Got error while commit: %v", err)
+	}
+
+	var v []byte
+	c.SetKey("k")
+	if err = c.Search(); err == nil {
+		err = c.GetValue(&v)
+	}
+
+	// Entries apply in order, each to the result of the one before.
+	if err != nil || string(v) != "0AB34-567Z9" {
+		t.Errorf("Modified value %q, %v, expected %q", v, err, "0AB34-567Z9")
+	}
+}
@@ -0,0 +1,421 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <stdint.h>
+#include <string.h>
+#include <errno.h>
+#include <wiredtiger.h>
+
+typedef struct {
+	WT_DATA_SOURCE iface;
+	uintptr_t handle;
+} GO_DATA_SOURCE;
+
+typedef struct {
+	WT_CURSOR iface;
+	uintptr_t handle;
+	char *key_format;
+	char *value_format;
+	void *kbuf;
+	size_t kbuf_size;
+	void *vbuf;
+	size_t vbuf_size;
+} GO_DS_CURSOR;
+
+enum {
+	GO_DS_CREATE, GO_DS_DROP, GO_DS_RENAME, GO_DS_TRUNCATE
+};
+
+enum {
+	GO_DS_CURSOR_NEXT, GO_DS_CURSOR_PREV, GO_DS_CURSOR_RESET, GO_DS_CURSOR_SEARCH, GO_DS_CURSOR_SEARCH_NEAR,
+	GO_DS_CURSOR_INSERT, GO_DS_CURSOR_UPDATE, GO_DS_CURSOR_REMOVE, GO_DS_CURSOR_CLOSE
+};
+
+extern int goDataSourceOp(uintptr_t handle, WT_SESSION *session, int op, char *uri, char *newuri, WT_CONFIG_ARG *config);
+extern int goDataSourceOpenCursor(uintptr_t handle, WT_SESSION *session, char *uri, WT_CONFIG_ARG *config, uintptr_t *cursor_handle, char **key_format, char **value_format);
+extern int goDataSourceCursorOp(uintptr_t handle, GO_DS_CURSOR *cursor, int op, int *exactp);
+extern void goDataSourceTerminate(uintptr_t handle);
+
+static int wiredtiger_ds_cursor_set_item(WT_ITEM *item, void **buf, size_t *buf_size, void *data, size_t size) {
+	void *p;
+
+	if (size > *buf_size) {
+		if ((p = realloc(*buf, size)) == NULL)
+			return ENOMEM;
+
+		*buf = p;
+		*buf_size = size;
+	}
+
+	if (size > 0)
+		memmove(*buf, data, size);
+
+	item->data = *buf;
+	item->size = size;
+
+	return 0;
+}
+
+static int wiredtiger_ds_cursor_set_key(GO_DS_CURSOR *c, void *data, size_t size) {
+	return wiredtiger_ds_cursor_set_item(&c->iface.key, &c->kbuf, &c->kbuf_size, data, size);
+}
+
+static int wiredtiger_ds_cursor_set_value(GO_DS_CURSOR *c, void *data, size_t size) {
+	return wiredtiger_ds_cursor_set_item(&c->iface.value, &c->vbuf, &c->vbuf_size, data, size);
+}
+
+static int wiredtiger_ds_cursor_notsup_get(WT_CURSOR *cursor, ...) {
+	return ENOTSUP;
+}
+
+static void wiredtiger_ds_cursor_notsup_set(WT_CURSOR *cursor, ...) {
+}
+
+static int wiredtiger_ds_cursor_notsup(WT_CURSOR *cursor) {
+	return ENOTSUP;
+}
+
+static int wiredtiger_ds_cursor_next(WT_CURSOR *cursor) {
+	return goDataSourceCursorOp(((GO_DS_CURSOR *)cursor)->handle, (GO_DS_CURSOR *)cursor, GO_DS_CURSOR_NEXT, NULL);
+}
+
+static int wiredtiger_ds_cursor_prev(WT_CURSOR *cursor) {
+	return goDataSourceCursorOp(((GO_DS_CURSOR *)cursor)->handle, (GO_DS_CURSOR *)cursor, GO_DS_CURSOR_PREV, NULL);
+}
+
+static int wiredtiger_ds_cursor_reset(WT_CURSOR *cursor) {
+	return goDataSourceCursorOp(((GO_DS_CURSOR *)cursor)->handle, (GO_DS_CURSOR *)cursor, GO_DS_CURSOR_RESET, NULL);
+}
+
+static int wiredtiger_ds_cursor_search(WT_CURSOR *cursor) {
+	return goDataSourceCursorOp(((GO_DS_CURSOR *)cursor)->handle, (GO_DS_CURSOR *)cursor, GO_DS_CURSOR_SEARCH, NULL);
+}
+
+static int wiredtiger_ds_cursor_search_near(WT_CURSOR *cursor, int *exactp) {
+	return goDataSourceCursorOp(((GO_DS_CURSOR *)cursor)->handle, (GO_DS_CURSOR *)cursor, GO_DS_CURSOR_SEARCH_NEAR, exactp);
+}
+
+static int wiredtiger_ds_cursor_insert(WT_CURSOR *cursor) {
+	return goDataSourceCursorOp(((GO_DS_CURSOR *)cursor)->handle, (GO_DS_CURSOR *)cursor, GO_DS_CURSOR_INSERT, NULL);
+}
+
+static int wiredtiger_ds_cursor_update(WT_CURSOR *cursor) {
+	return goDataSourceCursorOp(((GO_DS_CURSOR *)cursor)->handle, (GO_DS_CURSOR *)cursor, GO_DS_CURSOR_UPDATE, NULL);
+}
+
+static int wiredtiger_ds_cursor_remove(WT_CURSOR *cursor) {
+	return goDataSourceCursorOp(((GO_DS_CURSOR *)cursor)->handle, (GO_DS_CURSOR *)cursor, GO_DS_CURSOR_REMOVE, NULL);
+}
+
+static int wiredtiger_ds_cursor_close(WT_CURSOR *cursor) {
+	GO_DS_CURSOR *c = (GO_DS_CURSOR *)cursor;
+	int ret;
+
+	ret = goDataSourceCursorOp(c->handle, c, GO_DS_CURSOR_CLOSE, NULL);
+
+	free(c->key_format);
+	free(c->value_format);
+	free(c->kbuf);
+	free(c->vbuf);
+	free(c);
+
+	return ret;
+}
+
+static int wiredtiger_ds_create(WT_DATA_SOURCE *dsrc, WT_SESSION *session, const char *uri, WT_CONFIG_ARG *config) {
+	return goDataSourceOp(((GO_DATA_SOURCE *)dsrc)->handle, session, GO_DS_CREATE, (char *)uri, NULL, config);
+}
+
+static int wiredtiger_ds_drop(WT_DATA_SOURCE *dsrc, WT_SESSION *session, const char *uri, WT_CONFIG_ARG *config) {
+	return goDataSourceOp(((GO_DATA_SOURCE *)dsrc)->handle, session, GO_DS_DROP, (char *)uri, NULL, config);
+}
+
+static int wiredtiger_ds_rename(WT_DATA_SOURCE *dsrc, WT_SESSION *session, const char *uri, const char *newuri, WT_CONFIG_ARG *config) {
+	return goDataSourceOp(((GO_DATA_SOURCE *)dsrc)->handle, session, GO_DS_RENAME, (char *)uri, (char *)newuri, config);
+}
+
+static int wiredtiger_ds_truncate(WT_DATA_SOURCE *dsrc, WT_SESSION *session, const char *uri, WT_CONFIG_ARG *config) {
+	return goDataSourceOp(((GO_DATA_SOURCE *)dsrc)->handle, session, GO_DS_TRUNCATE, (char *)uri, NULL, config);
+}
+
+static int wiredtiger_ds_open_cursor(WT_DATA_SOURCE *dsrc, WT_SESSION *session, const char *uri, WT_CONFIG_ARG *config, WT_CURSOR **new_cursor) {
+	GO_DS_CURSOR *c;
+	uintptr_t handle = 0;
+	char *key_format = NULL, *value_format = NULL;
+	int ret;
+
+	if ((ret = goDataSourceOpenCursor(((GO_DATA_SOURCE *)dsrc)->handle, session, (char *)uri, config, &handle, &key_format, &value_format)) != 0)
+		return ret;
+
+	if ((c = calloc(1, sizeof(GO_DS_CURSOR))) == NULL) {
+		goDataSourceCursorOp(handle, NULL, GO_DS_CURSOR_CLOSE, NULL);
+		free(key_format);
+		free(value_format);
+		return ENOMEM;
+	}
+
+	c->handle = handle;
+	c->key_format = key_format;
+	c->value_format = value_format;
+
+	c->iface.key_format = key_format;
+	c->iface.value_format = value_format;
+	c->iface.get_key = wiredtiger_ds_cursor_notsup_get;
+	c->iface.get_value = wiredtiger_ds_cursor_notsup_get;
+	c->iface.set_key = wiredtiger_ds_cursor_notsup_set;
+	c->iface.set_value = wiredtiger_ds_cursor_notsup_set;
+	c->iface.next = wiredtiger_ds_cursor_next;
+	c->iface.prev = wiredtiger_ds_cursor_prev;
+	c->iface.reset = wiredtiger_ds_cursor_reset;
+	c->iface.search = wiredtiger_ds_cursor_search;
+	c->iface.search_near = wiredtiger_ds_cursor_search_near;
+	c->iface.insert = wiredtiger_ds_cursor_insert;
+	c->iface.update = wiredtiger_ds_cursor_update;
+	c->iface.remove = wiredtiger_ds_cursor_remove;
+	c->iface.reserve = wiredtiger_ds_cursor_notsup;
+	c->iface.close = wiredtiger_ds_cursor_close;
+
+	*new_cursor = &c->iface;
+	return 0;
+}
+
+static int wiredtiger_ds_terminate(WT_DATA_SOURCE *dsrc, WT_SESSION *session) {
+	goDataSourceTerminate(((GO_DATA_SOURCE *)dsrc)->handle);
+	free(dsrc);
+
+	return 0;
+}
+
+static int wiredtiger_connection_add_data_source(WT_CONNECTION *connection, const char *prefix, uintptr_t handle, const char *config) {
+	GO_DATA_SOURCE *d;
+	int ret;
+
+	if ((d = calloc(1, sizeof(GO_DATA_SOURCE))) == NULL)
+		return ENOMEM;
+
+	d->iface.create = wiredtiger_ds_create;
+	d->iface.drop = wiredtiger_ds_drop;
+	d->iface.open_cursor = wiredtiger_ds_open_cursor;
+	d->iface.rename = wiredtiger_ds_rename;
+	d->iface.truncate = wiredtiger_ds_truncate;
+	d->iface.terminate = wiredtiger_ds_terminate;
+	d->handle = handle;
+
+	if ((ret = connection->add_data_source(connection, prefix, &d->iface, config)) != 0)
+		free(d);
+
+	return ret;
+}
+*/
+import "C"
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+// DataSource implements the objects under a URI prefix added with
+// AddDataSource. Session.Create, Session.Drop and Session.OpenCursor on URIs
+// with that prefix are dispatched to it.
+type DataSource interface {
+	Create(session *Session, uri string, config *ExtensionConfig) error
+	Drop(session *Session, uri string, config *ExtensionConfig) error
+	OpenCursor(session *Session, uri string, config *ExtensionConfig) (DataSourceCursor, error)
+}
+
+// DataSourceRenamer is implemented by data sources supporting Session.Rename.
+type DataSourceRenamer interface {
+	Rename(session *Session, uri, newURI string, config *ExtensionConfig) error
+}
+
+// DataSourceTruncater is implemented by data sources supporting
+// Session.Truncate of a whole object.
+type DataSourceTruncater interface {
+	Truncate(session *Session, uri string, config *ExtensionConfig) error
+}
+
+// DataSourceCursor is a cursor over a Go data source. Keys and values are
+// in their packed form, as described by Formats; only row-store key formats
+// are supported.
+//
+// Positioning methods return the key and value the cursor is positioned on,
+// and an error with code WT_NOTFOUND when there is none. The slices passed in
+// are only valid during the call and returned slices are copied before the
+// next call. Settings such as "overwrite" are found in the configuration
+// passed to DataSource.OpenCursor.
+type DataSourceCursor interface {
+	Formats() (keyFormat, valueFormat string)
+	Next() (key, value []byte, err error)
+	Prev() (key, value []byte, err error)
+	Reset() error
+	Search(key []byte) (value []byte, err error)
+	SearchNear(key []byte) (foundKey, value []byte, exact int, err error)
+	Insert(key, value []byte) error
+	Update(key, value []byte) error
+	Remove(key []byte) error
+	Close() error
+}
+
+// AddDataSource registers source for URIs starting with prefix, which must
+// end with a colon, e.g. "gomem:".
+func (c *Connection) AddDataSource(prefix string, source DataSource) error {
+	var prefixC *C.char = nil
+
+	if source == nil {
+		return NewError(EINVAL, nil)
+	}
+
+	if len(prefix) > 0 {
+		prefixC = C.CString(prefix)
+		defer C.free(unsafe.Pointer(prefixC))
+	}
+
+	h := cgo.NewHandle(source)
+
+	if res := int(C.wiredtiger_connection_add_data_source(c.w, prefixC, C.uintptr_t(h), nil)); res != 0 {
+		h.Delete()
+		return NewError(res, nil)
+	}
+
+	return nil
+}
+
+//export goDataSourceOp
+func goDataSourceOp(handle C.uintptr_t, session *C.WT_SESSION, op C.int, uri *C.char, newuri *C.char, config *C.WT_CONFIG_ARG) (res C.int) {
+	defer callbackRecover(&res)
+
+	ds := cgo.Handle(handle).Value().(DataSource)
+	s := lookupSession(session)
+	cfg := &ExtensionConfig{conn: session.connection, session: session, w: config}
+
+	switch op {
+	case C.GO_DS_CREATE:
+		return callbackResult(ds.Create(s, C.GoString(uri), cfg))
+	case C.GO_DS_DROP:
+		return callbackResult(ds.Drop(s, C.GoString(uri), cfg))
+	case C.GO_DS_RENAME:
+		if r, ok := ds.(DataSourceRenamer); ok {
+			return callbackResult(r.Rename(s, C.GoString(uri), C.GoString(newuri), cfg))
+		}
+	case C.GO_DS_TRUNCATE:
+		if t, ok := ds.(DataSourceTruncater); ok {
+			return callbackResult(t.Truncate(s, C.GoString(uri), cfg))
+		}
+	}
+
+	return C.ENOTSUP
+}
+
+//export goDataSourceOpenCursor
+func goDataSourceOpenCursor(handle C.uintptr_t, session *C.WT_SESSION, uri *C.char, config *C.WT_CONFIG_ARG, cursorHandle *C.uintptr_t, keyFormat **C.char, valueFormat **C.char) (res C.int) {
+	defer callbackRecover(&res)
+
+	ds := cgo.Handle(handle).Value().(DataSource)
+	cfg := &ExtensionConfig{conn: session.connection, session: session, w: config}
+
+	dc, err := ds.OpenCursor(lookupSession(session), C.GoString(uri), cfg)
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	if dc == nil {
+		return C.int(WT_ERROR)
+	}
+
+	kf, vf := dc.Formats()
+
+	*keyFormat = C.CString(kf)
+	*valueFormat = C.CString(vf)
+	*cursorHandle = C.uintptr_t(cgo.NewHandle(dc))
+
+	return 0
+}
+
+func dsCursorSetKey(c *C.GO_DS_CURSOR, key []byte) C.int {
+	var p unsafe.Pointer
+
+	if len(key) > 0 {
+		p = unsafe.Pointer(&key[0])
+	}
+
+	return C.wiredtiger_ds_cursor_set_key(c, p, C.size_t(len(key)))
+}
+
+func dsCursorSetValue(c *C.GO_DS_CURSOR, value []byte) C.int {
+	var p unsafe.Pointer
+
+	if len(value) > 0 {
+		p = unsafe.Pointer(&value[0])
+	}
+
+	return C.wiredtiger_ds_cursor_set_value(c, p, C.size_t(len(value)))
+}
+
+func dsCursorSetKeyValue(c *C.GO_DS_CURSOR, key, value []byte) C.int {
+	if res := dsCursorSetKey(c, key); res != 0 {
+		return res
+	}
+
+	return dsCursorSetValue(c, value)
+}
+
+//export goDataSourceCursorOp
+func goDataSourceCursorOp(handle C.uintptr_t, cursor *C.GO_DS_CURSOR, op C.int, exact *C.int) (res C.int) {
+	var key, value []byte
+	var err error
+
+	defer callbackRecover(&res)
+
+	h := cgo.Handle(handle)
+	dc := h.Value().(DataSourceCursor)
+
+	switch op {
+	case C.GO_DS_CURSOR_NEXT:
+		if key, value, err = dc.Next(); err == nil {
+			return dsCursorSetKeyValue(cursor, key, value)
+		}
+	case C.GO_DS_CURSOR_PREV:
+		if key, value, err = dc.Prev(); err == nil {
+			return dsCursorSetKeyValue(cursor, key, value)
+		}
+	case C.GO_DS_CURSOR_RESET:
+		err = dc.Reset()
+	case C.GO_DS_CURSOR_SEARCH:
+		key = borrowBytes(unsafe.Pointer(cursor.iface.key.data), cursor.iface.key.size)
+		if value, err = dc.Search(key); err == nil {
+			return dsCursorSetValue(cursor, value)
+		}
+	case C.GO_DS_CURSOR_SEARCH_NEAR:
+		var cmp int
+
+		key = borrowBytes(unsafe.Pointer(cursor.iface.key.data), cursor.iface.key.size)
+		if key, value, cmp, err = dc.SearchNear(key); err == nil {
+			*exact = C.int(cmp)
+			return dsCursorSetKeyValue(cursor, key, value)
+		}
+	case C.GO_DS_CURSOR_INSERT:
+		key = borrowBytes(unsafe.Pointer(cursor.iface.key.data), cursor.iface.key.size)
+		value = borrowBytes(unsafe.Pointer(cursor.iface.value.data), cursor.iface.value.size)
+		err = dc.Insert(key, value)
+	case C.GO_DS_CURSOR_UPDATE:
+		key = borrowBytes(unsafe.Pointer(cursor.iface.key.data), cursor.iface.key.size)
+		value = borrowBytes(unsafe.Pointer(cursor.iface.value.data), cursor.iface.value.size)
+		err = dc.Update(key, value)
+	case C.GO_DS_CURSOR_REMOVE:
+		key = borrowBytes(unsafe.Pointer(cursor.iface.key.data), cursor.iface.key.size)
+		err = dc.Remove(key)
+	case C.GO_DS_CURSOR_CLOSE:
+		err = dc.Close()
+		h.Delete()
+	default:
+		return C.ENOTSUP
+	}
+
+	return callbackResult(err)
+}
+
+//export goDataSourceTerminate
+func goDataSourceTerminate(handle C.uintptr_t) {
+	cgo.Handle(handle).Delete()
+}
@@ -0,0 +1,299 @@
+package wiredtiger
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+)
+
+type memEntry struct {
+	key, value []byte
+}
+
+type memObject struct {
+	keyFormat, valueFormat string
+	entries                []memEntry
+}
+
+// find returns the position of the first entry not below key, and whether
+// it holds key.
+func (o *memObject) find(key []byte) (int, bool) {
+	i := sort.Search(len(o.entries), func(i int) bool { return bytes.Compare(o.entries[i].key, key) >= 0 })
+	return i, i < len(o.entries) && bytes.Equal(o.entries[i].key, key)
+}
+
+// memDataSource keeps its objects in sorted slices, recording the calls
+// made to it.
+type memDataSource struct {
+	mu      sync.Mutex
+	objects map[string]*memObject
+	calls   []string
+}
+
+func (ds *memDataSource) Create(session *Session, uri string, config *ExtensionConfig) error {
+	keyFormat, err := config.Get("key_format")
+	if err != nil {
+		return err
+	}
+
+	valueFormat, err := config.Get("value_format")
+	if err != nil {
+		return err
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	ds.calls = append(ds.calls, "create "+uri)
+	ds.objects[uri] = &memObject{keyFormat: keyFormat, valueFormat: valueFormat}
+	return nil
+}
+
+func (ds *memDataSource) Drop(session *Session, uri string, config *ExtensionConfig) error {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if ds.objects[uri] == nil {
+		return syscall.ENOENT
+	}
+
+	ds.calls = append(ds.calls, "drop "+uri)
+	delete(ds.objects, uri)
+	return nil
+}
+
+func (ds *memDataSource) OpenCursor(session *Session, uri string, config *ExtensionConfig) (DataSourceCursor, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	o := ds.objects[uri]
+	if o == nil {
+		return nil, syscall.ENOENT
+	}
+
+	ds.calls = append(ds.calls, "open "+uri)
+	return &memCursor{ds: ds, o: o, pos: -1}, nil
+}
+
+// memCursor is positioned on entries[pos], or on nothing when pos is -1.
+type memCursor struct {
+	ds  *memDataSource
+	o   *memObject
+	pos int
+}
+
+func (c *memCursor) Formats() (string, string) {
+	return c.o.keyFormat, c.o.valueFormat
+}
+
+func (c *memCursor) step(next bool) ([]byte, []byte, error) {
+	c.ds.mu.Lock()
+	defer c.ds.mu.Unlock()
+
+	switch {
+	case next:
+		c.pos++
+	case c.pos == -1:
+		c.pos = len(c.o.entries) - 1
+	default:
+		c.pos--
+	}
+
+	if c.pos < 0 || c.pos >= len(c.o.entries) {
+		c.pos = -1
+		return nil, nil, NewError(WT_NOTFOUND, nil)
+	}
+
+	e := c.o.entries[c.pos]
+	return e.key, e.value, nil
+}
+
+func (c *memCursor) Next() ([]byte, []byte, error) {
+	return c.step(true)
+}
+
+func (c *memCursor) Prev() ([]byte, []byte, error) {
+	return c.step(false)
+}
+
+func (c *memCursor) Reset() error {
+	c.ds.mu.Lock()
+	defer c.ds.mu.Unlock()
+
+	c.pos = -1
+	return nil
+}
+
+func (c *memCursor) Search(key []byte) ([]byte, error) {
+	c.ds.mu.Lock()
+	defer c.ds.mu.Unlock()
+
+	i, ok := c.o.find(key)
+	if !ok {
+		return nil, NewError(WT_NOTFOUND, nil)
+	}
+
+	c.pos = i
+	return c.o.entries[i].value, nil
+}
+
+func (c *memCursor) SearchNear(key []byte) ([]byte, []byte, int, error) {
+	c.ds.mu.Lock()
+	defer c.ds.mu.Unlock()
+
+	if len(c.o.entries) == 0 {
+		return nil, nil, 0, NewError(WT_NOTFOUND, nil)
+	}
+
+	i, ok := c.o.find(key)
+	exact := 0
+
+	switch {
+	case i == len(c.o.entries):
+		i--
+		exact = -1
+	case !ok:
+		exact = 1
+	}
+
+	c.pos = i
+	return c.o.entries[i].key, c.o.entries[i].value, exact, nil
+}
+
+func (c *memCursor) Insert(key, value []byte) error {
+	c.ds.mu.Lock()
+	defer c.ds.mu.Unlock()
+
+	e := memEntry{bytes.Clone(key), bytes.Clone(value)}
+
+	if i, ok := c.o.find(key); ok {
+		c.o.entries[i] = e
+	} else {
+		c.o.entries = append(c.o.entries[:i], append([]memEntry{e}, c.o.entries[i:]...)...)
+	}
+
+	return nil
+}
+
+func (c *memCursor) Update(key, value []byte) error {
+	c.ds.mu.Lock()
+	defer c.ds.mu.Unlock()
+
+	i, ok := c.o.find(key)
+	if !ok {
+		return NewError(WT_NOTFOUND, nil)
+	}
+
+	c.o.entries[i].value = bytes.Clone(value)
+	return nil
+}
+
+func (c *memCursor) Remove(key []byte) error {
+	c.ds.mu.Lock()
+	defer c.ds.mu.Unlock()
+
+	i, ok := c.o.find(key)
+	if !ok {
+		return NewError(WT_NOTFOUND, nil)
+	}
+
+	c.o.entries = append(c.o.entries[:i], c.o.entries[i+1:]...)
+	return nil
+}
+
+func (c *memCursor) Close() error {
+	c.ds.mu.Lock()
+	defer c.ds.mu.Unlock()
+
+	c.ds.calls = append(c.ds.calls, "close")
+	return nil
+}
+
+func TestDataSource(t *testing.T) {
+	conn, session := openTestSession(t, "")
+
+	ds := &memDataSource{objects: make(map[string]*memObject)}
+
+	if err := conn.AddDataSource("gomem:", ds); err != nil {
+		t.Fatalf("Got error while add data source: %v", err)
+	}
+
+	if err := session.Create("gomem:fruit", "key_format=S,value_format=q"); err != nil {
+		t.Fatalf("Got error while create: %v", err)
+	}
+
+	if o := ds.objects["gomem:fruit"]; o == nil || o.keyFormat != "S" || o.valueFormat != "q" {
+		t.Fatalf("Got object %+v, expected formats S and q", o)
+	}
+
+	c, err := session.OpenCursor("gomem:fruit", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+
+	for i, k := range []string{"pear", "apple", "fig"} {
+		c.SetKey(k)
+		c.SetValue(int64(i + 1))
+		if err = c.Insert(); err != nil {
+			t.Fatalf("Got error while insert: %v", err)
+		}
+	}
+
+	var v int64
+
+	c.SetKey("apple")
+	if err = c.Search(); err == nil {
+		err = c.GetValue(&v)
+	}
+	if err != nil || v != 2 {
+		t.Errorf("Search returned %d, %v, expected 2", v, err)
+	}
+
+	c.SetKey("banana")
+	if err = c.Search(); err == nil {
+		t.Error("Expected an error while search a missing key")
+	}
+
+	if err = c.Reset(); err != nil {
+		t.Fatalf("Got error while reset: %v", err)
+	}
+
+	var rows []string
+
+	for c.Next() == nil {
+		var k string
+
+		if err = c.GetKey(&k); err == nil {
+			err = c.GetValue(&v)
+		}
+		if err != nil {
+			t.Fatalf("Got error while read row: %v", err)
+		}
+
+		rows = append(rows, k+"="+strconv.FormatInt(v, 10))
+	}
+
+	if got, expected := strings.Join(rows, ","), "apple=2,fig=3,pear=1"; got != expected {
+		t.Errorf("Got rows %q, expected %q", got, expected)
+	}
+
+	if err = c.Close(); err != nil {
+		t.Fatalf("Got error while close cursor: %v", err)
+	}
+
+	if err = session.Drop("gomem:fruit", ""); err != nil {
+		t.Fatalf("Got error while drop: %v", err)
+	}
+
+	if ds.objects["gomem:fruit"] != nil {
+		t.Error("Expected Drop to remove the object")
+	}
+
+	if got, expected := strings.Join(ds.calls, ","), "create gomem:fruit,open gomem:fruit,close,drop gomem:fruit"; got != expected {
+		t.Errorf("Got calls %q, expected %q", got, expected)
+	}
+}
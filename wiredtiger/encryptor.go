@@ -0,0 +1,226 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <stdint.h>
+#include <errno.h>
+#include <wiredtiger.h>
+
+typedef struct {
+	WT_ENCRYPTOR iface;
+	uintptr_t handle;
+} GO_ENCRYPTOR;
+
+extern int goEncryptorEncrypt(uintptr_t handle, void *src, size_t src_len, void *dst, size_t dst_len, size_t *result_lenp);
+extern int goEncryptorDecrypt(uintptr_t handle, void *src, size_t src_len, void *dst, size_t dst_len, size_t *result_lenp);
+extern int goEncryptorSizing(uintptr_t handle, size_t *expansion_constantp);
+extern int goEncryptorCustomize(uintptr_t handle, WT_SESSION *session, WT_CONFIG_ARG *encrypt_config, uintptr_t *customp);
+extern void goEncryptorTerminate(uintptr_t handle);
+
+static GO_ENCRYPTOR *wiredtiger_encryptor_new(uintptr_t handle);
+
+static int wiredtiger_encryptor_encrypt(WT_ENCRYPTOR *encryptor, WT_SESSION *session, uint8_t *src, size_t src_len, uint8_t *dst, size_t dst_len, size_t *result_lenp) {
+	return goEncryptorEncrypt(((GO_ENCRYPTOR *)encryptor)->handle, src, src_len, dst, dst_len, result_lenp);
+}
+
+static int wiredtiger_encryptor_decrypt(WT_ENCRYPTOR *encryptor, WT_SESSION *session, uint8_t *src, size_t src_len, uint8_t *dst, size_t dst_len, size_t *result_lenp) {
+	return goEncryptorDecrypt(((GO_ENCRYPTOR *)encryptor)->handle, src, src_len, dst, dst_len, result_lenp);
+}
+
+static int wiredtiger_encryptor_sizing(WT_ENCRYPTOR *encryptor, WT_SESSION *session, size_t *expansion_constantp) {
+	return goEncryptorSizing(((GO_ENCRYPTOR *)encryptor)->handle, expansion_constantp);
+}
+
+static int wiredtiger_encryptor_customize(WT_ENCRYPTOR *encryptor, WT_SESSION *session, WT_CONFIG_ARG *encrypt_config, WT_ENCRYPTOR **customp) {
+	GO_ENCRYPTOR *custom;
+	uintptr_t handle = 0;
+	int ret;
+
+	if ((ret = goEncryptorCustomize(((GO_ENCRYPTOR *)encryptor)->handle, session, encrypt_config, &handle)) != 0)
+		return ret;
+
+	if (handle == 0) {
+		*customp = NULL;
+		return 0;
+	}
+
+	if ((custom = wiredtiger_encryptor_new(handle)) == NULL) {
+		goEncryptorTerminate(handle);
+		return ENOMEM;
+	}
+
+	*customp = &custom->iface;
+	return 0;
+}
+
+static int wiredtiger_encryptor_terminate(WT_ENCRYPTOR *encryptor, WT_SESSION *session) {
+	goEncryptorTerminate(((GO_ENCRYPTOR *)encryptor)->handle);
+	free(encryptor);
+
+	return 0;
+}
+
+static GO_ENCRYPTOR *wiredtiger_encryptor_new(uintptr_t handle) {
+	GO_ENCRYPTOR *e;
+
+	if ((e = calloc(1, sizeof(GO_ENCRYPTOR))) == NULL)
+		return NULL;
+
+	e->iface.encrypt = wiredtiger_encryptor_encrypt;
+	e->iface.decrypt = wiredtiger_encryptor_decrypt;
+	e->iface.sizing = wiredtiger_encryptor_sizing;
+	e->iface.customize = wiredtiger_encryptor_customize;
+	e->iface.terminate = wiredtiger_encryptor_terminate;
+	e->handle = handle;
+
+	return e;
+}
+
+static int wiredtiger_connection_add_encryptor(WT_CONNECTION *connection, const char *name, uintptr_t handle, const char *config) {
+	GO_ENCRYPTOR *e;
+	int ret;
+
+	if ((e = wiredtiger_encryptor_new(handle)) == NULL)
+		return ENOMEM;
+
+	if ((ret = connection->add_encryptor(connection, name, &e->iface, config)) != 0)
+		free(e);
+
+	return ret;
+}
+*/
+import "C"
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+// Encryptor encrypts the blocks, log records and metadata of a connection or
+// of tables created with "encryption=(name=...)".
+//
+// Encrypt writes the encrypted form of src into dst and returns its length.
+// dst is at least Sizing bytes longer than src. Decrypt writes the original
+// data into dst and returns its length. src and dst are only valid during
+// the call, and the methods are called concurrently from WiredTiger's
+// threads.
+type Encryptor interface {
+	Encrypt(dst, src []byte) (int, error)
+	Decrypt(dst, src []byte) (int, error)
+	Sizing() int
+}
+
+// EncryptorCustomizer is implemented by encryptors that need a separate
+// instance per "keyid". Customize is called once for each keyid used by the
+// connection and its tables, with the keyid and secretkey of the
+// "encryption" configuration. Returning a nil Encryptor keeps the original.
+type EncryptorCustomizer interface {
+	Customize(keyID, secretKey string) (Encryptor, error)
+}
+
+// AddEncryptor registers encryptor under name. An encryptor used by the
+// connection's own "encryption" setting must be added while wiredtiger_open
+// runs, from a Go extension, see RegisterExtension.
+func (c *Connection) AddEncryptor(name string, encryptor Encryptor) error {
+	var nameC *C.char = nil
+
+	if encryptor == nil {
+		return NewError(EINVAL, nil)
+	}
+
+	if len(name) > 0 {
+		nameC = C.CString(name)
+		defer C.free(unsafe.Pointer(nameC))
+	}
+
+	h := cgo.NewHandle(encryptor)
+
+	if res := int(C.wiredtiger_connection_add_encryptor(c.w, nameC, C.uintptr_t(h), nil)); res != 0 {
+		h.Delete()
+		return NewError(res, nil)
+	}
+
+	return nil
+}
+
+//export goEncryptorEncrypt
+func goEncryptorEncrypt(handle C.uintptr_t, src unsafe.Pointer, srcLen C.size_t, dst unsafe.Pointer, dstLen C.size_t, resultLen *C.size_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	e := cgo.Handle(handle).Value().(Encryptor)
+
+	n, err := e.Encrypt(borrowBytes(dst, dstLen), borrowBytes(src, srcLen))
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	if n < 0 || n > int(dstLen) {
+		return C.int(WT_ERROR)
+	}
+
+	*resultLen = C.size_t(n)
+	return 0
+}
+
+//export goEncryptorDecrypt
+func goEncryptorDecrypt(handle C.uintptr_t, src unsafe.Pointer, srcLen C.size_t, dst unsafe.Pointer, dstLen C.size_t, resultLen *C.size_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	e := cgo.Handle(handle).Value().(Encryptor)
+
+	n, err := e.Decrypt(borrowBytes(dst, dstLen), borrowBytes(src, srcLen))
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	if n < 0 || n > int(dstLen) {
+		return C.int(WT_ERROR)
+	}
+
+	*resultLen = C.size_t(n)
+	return 0
+}
+
+//export goEncryptorSizing
+func goEncryptorSizing(handle C.uintptr_t, expansion *C.size_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	e := cgo.Handle(handle).Value().(Encryptor)
+	*expansion = C.size_t(e.Sizing())
+
+	return 0
+}
+
+//export goEncryptorCustomize
+func goEncryptorCustomize(handle C.uintptr_t, session *C.WT_SESSION, config *C.WT_CONFIG_ARG, custom *C.uintptr_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	*custom = 0
+
+	c, ok := cgo.Handle(handle).Value().(EncryptorCustomizer)
+	if !ok {
+		return 0
+	}
+
+	cfg := &ExtensionConfig{conn: session.connection, session: session, w: config}
+
+	// Both settings are optional.
+	keyID, _ := cfg.Get("keyid")
+	secretKey, _ := cfg.Get("secretkey")
+
+	e, err := c.Customize(keyID, secretKey)
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	if e != nil {
+		*custom = C.uintptr_t(cgo.NewHandle(e))
+	}
+
+	return 0
+}
+
+//export goEncryptorTerminate
+func goEncryptorTerminate(handle C.uintptr_t) {
+	cgo.Handle(handle).Delete()
+}
@@ -0,0 +1,147 @@
+package wiredtiger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func newTestAESGCMEncryptor(t *testing.T) (Encryptor, *[]string) {
+	t.Helper()
+
+	var lookups []string
+
+	keys := map[string][]byte{
+		"one":   bytes.Repeat([]byte{1}, 32),
+		"two":   bytes.Repeat([]byte{2}, 32),
+		"short": bytes.Repeat([]byte{3}, 16),
+	}
+
+	e, err := NewAESGCMEncryptor(func(keyID string) ([]byte, error) {
+		lookups = append(lookups, keyID)
+
+		if key, ok := keys[keyID]; ok {
+			return key, nil
+		}
+
+		return nil, errors.New("unknown key")
+	})
+	if err != nil {
+		t.Fatalf("Got error while create encryptor: %v", err)
+	}
+
+	return e, &lookups
+}
+
+func customizeTestEncryptor(t *testing.T, e Encryptor, keyID string) Encryptor {
+	t.Helper()
+
+	c, err := e.(EncryptorCustomizer).Customize(keyID, "")
+	if err != nil {
+		t.Fatalf("Got error while customize for %q: %v", keyID, err)
+	}
+
+	return c
+}
+
+func encryptTest(t *testing.T, e Encryptor, src []byte) []byte {
+	t.Helper()
+
+	dst := make([]byte, len(src)+e.Sizing())
+
+	n, err := e.Encrypt(dst, src)
+	if err != nil {
+		t.Fatalf("Got error while encrypt: %v", err)
+	}
+
+	return dst[:n]
+}
+
+func TestAESGCMEncryptorCustomize(t *testing.T) {
+	if _, err := NewAESGCMEncryptor(nil); err == nil {
+		t.Error("Expected an error for a nil KeyLookup")
+	}
+
+	e, lookups := newTestAESGCMEncryptor(t)
+	customizeTestEncryptor(t, e, "one")
+
+	if len(*lookups) != 1 || (*lookups)[0] != "one" {
+		t.Errorf("Got lookups %q, expected the keyid", *lookups)
+	}
+
+	for _, keyID := range []string{"missing", "short"} {
+		if _, err := e.(EncryptorCustomizer).Customize(keyID, ""); err == nil {
+			t.Errorf("Expected an error while customize for %q", keyID)
+		}
+	}
+}
+
+func TestAESGCMEncryptorRoundTrip(t *testing.T) {
+	e, _ := newTestAESGCMEncryptor(t)
+	one := customizeTestEncryptor(t, e, "one")
+
+	src := []byte("a page of WiredTiger data")
+	sealed := encryptTest(t, one, src)
+
+	if len(sealed) != len(src)+one.Sizing() {
+		t.Errorf("Got %d encrypted bytes, expected %d", len(sealed), len(src)+one.Sizing())
+	}
+
+	if bytes.Contains(sealed, src) {
+		t.Error("Expected the data to be encrypted")
+	}
+
+	// A fresh nonce for every block.
+	if bytes.Equal(sealed, encryptTest(t, one, src)) {
+		t.Error("Expected two encryptions of the same data to differ")
+	}
+
+	dst := make([]byte, len(src))
+
+	n, err := one.Decrypt(dst, sealed)
+	if err != nil || !bytes.Equal(dst[:n], src) {
+		t.Fatalf("Decrypt returned %q, %v", dst[:n], err)
+	}
+
+	// Another keyid resolves to another key.
+	if _, err = customizeTestEncryptor(t, e, "two").Decrypt(dst, sealed); err == nil {
+		t.Error("Expected an error while decrypt with another key")
+	}
+
+	if _, err = one.Decrypt(dst, sealed[:one.Sizing()-1]); err == nil {
+		t.Error("Expected an error while decrypt truncated data")
+	}
+
+	if _, err = one.Encrypt(make([]byte, len(src)), src); err == nil {
+		t.Error("Expected an error while encrypt into a short buffer")
+	}
+}
+
+func TestAESGCMEncryptorTampered(t *testing.T) {
+	e, _ := newTestAESGCMEncryptor(t)
+	one := customizeTestEncryptor(t, e, "one")
+
+	src := []byte("a page of WiredTiger data")
+	sealed := encryptTest(t, one, src)
+
+	for i := range sealed {
+		tampered := bytes.Clone(sealed)
+		tampered[i] ^= 0x01
+
+		if _, err := one.Decrypt(make([]byte, len(src)), tampered); err == nil {
+			t.Fatalf("Expected authentication to fail with byte %d flipped", i)
+		}
+	}
+}
+
+func TestAESGCMEncryptorNoKey(t *testing.T) {
+	e, _ := newTestAESGCMEncryptor(t)
+
+	if _, err := e.Encrypt(make([]byte, 64), []byte("data")); err != errNoKey {
+		t.Errorf("Encrypt returned %v, expected %v", err, errNoKey)
+	}
+
+	if _, err := e.Decrypt(make([]byte, 64), make([]byte, 64)); err != errNoKey {
+		t.Errorf("Decrypt returned %v, expected %v", err, errNoKey)
+	}
+}
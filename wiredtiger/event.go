@@ -0,0 +1,302 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <stdint.h>
+#include <wiredtiger.h>
+
+typedef struct {
+	WT_EVENT_HANDLER iface;
+	uintptr_t handle;
+} GO_EVENT_HANDLER;
+
+extern int goEventHandleError(uintptr_t handle, WT_SESSION *session, int error, char *message);
+extern int goEventHandleMessage(uintptr_t handle, WT_SESSION *session, char *message);
+extern int goEventHandleProgress(uintptr_t handle, WT_SESSION *session, char *operation, uint64_t progress);
+extern int goEventHandleClose(uintptr_t handle, WT_SESSION *session, WT_CURSOR *cursor);
+
+static int wiredtiger_event_handle_error(WT_EVENT_HANDLER *handler, WT_SESSION *session, int error, const char *message) {
+	return goEventHandleError(((GO_EVENT_HANDLER *)handler)->handle, session, error, (char *)message);
+}
+
+static int wiredtiger_event_handle_message(WT_EVENT_HANDLER *handler, WT_SESSION *session, const char *message) {
+	return goEventHandleMessage(((GO_EVENT_HANDLER *)handler)->handle, session, (char *)message);
+}
+
+static int wiredtiger_event_handle_progress(WT_EVENT_HANDLER *handler, WT_SESSION *session, const char *operation, uint64_t progress) {
+	return goEventHandleProgress(((GO_EVENT_HANDLER *)handler)->handle, session, (char *)operation, progress);
+}
+
+static int wiredtiger_event_handle_close(WT_EVENT_HANDLER *handler, WT_SESSION *session, WT_CURSOR *cursor) {
+	return goEventHandleClose(((GO_EVENT_HANDLER *)handler)->handle, session, cursor);
+}
+
+static GO_EVENT_HANDLER *wiredtiger_event_handler_new(uintptr_t handle) {
+	GO_EVENT_HANDLER *h;
+
+	if ((h = calloc(1, sizeof(GO_EVENT_HANDLER))) == NULL)
+		return NULL;
+
+	h->iface.handle_error = wiredtiger_event_handle_error;
+	h->iface.handle_message = wiredtiger_event_handle_message;
+	h->iface.handle_progress = wiredtiger_event_handle_progress;
+	h->iface.handle_close = wiredtiger_event_handle_close;
+	h->handle = handle;
+
+	return h;
+}
+*/
+import "C"
+import (
+	"errors"
+	"runtime/cgo"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// EventHandler receives the error, informational and progress messages
+// WiredTiger would otherwise write to stderr, and close notifications for
+// handles WiredTiger closes on the application's behalf.
+//
+// A non-nil error returned from a method tells WiredTiger the event was not
+// handled, in which case it falls back to its default behaviour.
+type EventHandler interface {
+	HandleError(session *Session, err error, message string) error
+	HandleMessage(session *Session, message string) error
+	HandleProgress(session *Session, operation string, progress uint64) error
+	HandleClose(session *Session, cursor *Cursor) error
+}
+
+type eventHandler struct {
+	w      *C.GO_EVENT_HANDLER
+	handle cgo.Handle
+}
+
+func newEventHandler(handler EventHandler) *eventHandler {
+	if handler == nil {
+		return nil
+	}
+
+	h := new(eventHandler)
+	h.handle = cgo.NewHandle(handler)
+	h.w = C.wiredtiger_event_handler_new(C.uintptr_t(h.handle))
+
+	if h.w == nil {
+		h.handle.Delete()
+		return nil
+	}
+
+	return h
+}
+
+func (h *eventHandler) iface() *C.WT_EVENT_HANDLER {
+	if h == nil {
+		return nil
+	}
+
+	return &h.w.iface
+}
+
+func (h *eventHandler) free() {
+	if h == nil {
+		return
+	}
+
+	C.free(unsafe.Pointer(h.w))
+	h.handle.Delete()
+}
+
+// Handles known to Go, used to hand callbacks the wrappers the application
+// already holds instead of fresh copies.
+
+var registry = struct {
+	sync.RWMutex
+	connections map[*C.WT_CONNECTION]*Connection
+	sessions    map[*C.WT_SESSION]*Session
+	opening     bool
+}{
+	connections: make(map[*C.WT_CONNECTION]*Connection),
+	sessions:    make(map[*C.WT_SESSION]*Session),
+}
+
+// Closing a connection closes its sessions, so their wrappers are dropped and
+// their event handlers released with it.
+func unregisterConnection(w *C.WT_CONNECTION) {
+	registry.Lock()
+	c := registry.connections[w]
+	delete(registry.connections, w)
+
+	for sw, s := range registry.sessions {
+		if s.conn == c {
+			delete(registry.sessions, sw)
+			s.w = nil
+			s.handler.free()
+			s.handler = nil
+		}
+	}
+	registry.Unlock()
+}
+
+// Extensions loaded by wiredtiger_open see the connection before Open
+// returns it, so the first lookup creates the wrapper Open hands out later.
+func lookupConnection(w *C.WT_CONNECTION) *Connection {
+	if w == nil {
+		return nil
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	c := registry.connections[w]
+	if c == nil {
+		c = &Connection{w: w, opening: registry.opening}
+		registry.connections[w] = c
+	}
+
+	return c
+}
+
+// Opens are serialised so that the wrappers extensions register during a
+// failed wiredtiger_open can be told apart and dropped: the WT_CONNECTION
+// they point to is gone, and a later open may be handed the same address.
+var openMu sync.Mutex
+
+func beginOpen() {
+	openMu.Lock()
+
+	registry.Lock()
+	registry.opening = true
+	registry.Unlock()
+}
+
+func endOpen(w *C.WT_CONNECTION) *Connection {
+	defer openMu.Unlock()
+
+	registry.Lock()
+	registry.opening = false
+	registry.Unlock()
+
+	c := lookupConnection(w)
+	c.opening = false
+
+	return c
+}
+
+func abandonOpen(w *C.WT_CONNECTION) {
+	defer openMu.Unlock()
+
+	registry.Lock()
+	registry.opening = false
+
+	dead := []*C.WT_CONNECTION{}
+	for cw, c := range registry.connections {
+		if c.opening || cw == w {
+			dead = append(dead, cw)
+		}
+	}
+	registry.Unlock()
+
+	for _, cw := range dead {
+		unregisterConnection(cw)
+	}
+}
+
+func registerSession(s *Session) {
+	registry.Lock()
+	registry.sessions[s.w] = s
+	registry.Unlock()
+}
+
+func unregisterSession(w *C.WT_SESSION) {
+	registry.Lock()
+	delete(registry.sessions, w)
+	registry.Unlock()
+}
+
+func lookupSession(w *C.WT_SESSION) *Session {
+	if w == nil {
+		return nil
+	}
+
+	registry.RLock()
+	s := registry.sessions[w]
+	registry.RUnlock()
+
+	if s == nil {
+		s = &Session{w: w, conn: lookupConnection(w.connection)}
+	}
+
+	return s
+}
+
+func callbackResult(err error) C.int {
+	var errno syscall.Errno
+
+	if err == nil {
+		return 0
+	}
+
+	if e, ok := err.(*Error); ok && e.Code != 0 {
+		return C.int(e.Code)
+	}
+
+	if errors.As(err, &errno) && errno != 0 {
+		return C.int(errno)
+	}
+
+	return C.int(WT_ERROR)
+}
+
+// A panic must not unwind through WiredTiger's stack.
+func callbackRecover(res *C.int) {
+	if r := recover(); r != nil {
+		*res = C.int(WT_ERROR)
+	}
+}
+
+//export goEventHandleError
+func goEventHandleError(handle C.uintptr_t, session *C.WT_SESSION, code C.int, message *C.char) (res C.int) {
+	defer callbackRecover(&res)
+
+	h := cgo.Handle(handle).Value().(EventHandler)
+	return callbackResult(h.HandleError(lookupSession(session), NewError(int(code), nil), C.GoString(message)))
+}
+
+//export goEventHandleMessage
+func goEventHandleMessage(handle C.uintptr_t, session *C.WT_SESSION, message *C.char) (res C.int) {
+	defer callbackRecover(&res)
+
+	h := cgo.Handle(handle).Value().(EventHandler)
+	return callbackResult(h.HandleMessage(lookupSession(session), C.GoString(message)))
+}
+
+//export goEventHandleProgress
+func goEventHandleProgress(handle C.uintptr_t, session *C.WT_SESSION, operation *C.char, progress C.uint64_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	h := cgo.Handle(handle).Value().(EventHandler)
+	return callbackResult(h.HandleProgress(lookupSession(session), C.GoString(operation), uint64(progress)))
+}
+
+//export goEventHandleClose
+func goEventHandleClose(handle C.uintptr_t, session *C.WT_SESSION, cursor *C.WT_CURSOR) (res C.int) {
+	var c *Cursor
+
+	defer callbackRecover(&res)
+
+	s := lookupSession(session)
+
+	if cursor != nil {
+		c = new(Cursor)
+		c.w = cursor
+		c.session = s
+		c.uri = C.GoString(cursor.uri)
+		c.keyFormat = C.GoString(cursor.key_format)
+		c.valueFormat = C.GoString(cursor.value_format)
+	}
+
+	h := cgo.Handle(handle).Value().(EventHandler)
+	return callbackResult(h.HandleClose(s, c))
+}
@@ -0,0 +1,128 @@
+package wiredtiger
+
+import (
+	"sync"
+	"testing"
+)
+
+type recordingHandler struct {
+	mu       sync.Mutex
+	sessions []*Session
+	errors   []string
+	messages []string
+}
+
+func (h *recordingHandler) HandleError(session *Session, err error, message string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sessions = append(h.sessions, session)
+	h.errors = append(h.errors, message)
+	return nil
+}
+
+func (h *recordingHandler) HandleMessage(session *Session, message string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.messages = append(h.messages, message)
+	return nil
+}
+
+func (h *recordingHandler) HandleProgress(session *Session, operation string, progress uint64) error {
+	return nil
+}
+
+func (h *recordingHandler) HandleClose(session *Session, cursor *Cursor) error {
+	return nil
+}
+
+func (h *recordingHandler) counts() (errors, messages int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.errors), len(h.messages)
+}
+
+func openHandlerTestConnection(t *testing.T, handler EventHandler) *Connection {
+	t.Helper()
+
+	conn, err := OpenWithHandler(t.TempDir(), handler, "create")
+	if err != nil {
+		t.Fatalf("Got error while open database: %v", err)
+	}
+
+	t.Cleanup(func() { conn.Close("") })
+
+	return conn
+}
+
+func TestEventHandlerErrors(t *testing.T) {
+	connHandler := new(recordingHandler)
+	conn := openHandlerTestConnection(t, connHandler)
+
+	session, err := conn.OpenSession("")
+	if err != nil {
+		t.Fatalf("Got error while open session: %v", err)
+	}
+
+	if err = session.Create("table:bad", "no_such_key=1"); err == nil {
+		t.Fatal("Expected an error for an unknown configuration key")
+	}
+
+	connErrors, _ := connHandler.counts()
+	if connErrors == 0 {
+		t.Fatal("Expected the connection handler to receive the error")
+	}
+
+	if connHandler.sessions[0] != session {
+		t.Error("Expected the error to carry the session wrapper in use")
+	}
+
+	// A session with a handler of its own takes its errors away from the
+	// connection's handler.
+	sessionHandler := new(recordingHandler)
+
+	own, err := conn.OpenSessionWithHandler(sessionHandler, "")
+	if err != nil {
+		t.Fatalf("Got error while open session: %v", err)
+	}
+
+	if err = own.Create("table:bad", "no_such_key=1"); err == nil {
+		t.Fatal("Expected an error for an unknown configuration key")
+	}
+
+	if n, _ := sessionHandler.counts(); n == 0 {
+		t.Error("Expected the session handler to receive the error")
+	} else if sessionHandler.sessions[0] != own {
+		t.Error("Expected the error to carry the session wrapper in use")
+	}
+
+	if n, _ := connHandler.counts(); n != connErrors {
+		t.Errorf("Connection handler got %d errors after the override, expected %d", n, connErrors)
+	}
+}
+
+func TestEventHandlerMessages(t *testing.T) {
+	conn := openHandlerTestConnection(t, new(recordingHandler))
+
+	// Builds without verbose support reject the key.
+	if err := conn.Reconfigure("verbose=[api]"); err != nil {
+		t.Skipf("Verbose messages unavailable: %v", err)
+	}
+
+	sessionHandler := new(recordingHandler)
+
+	session, err := conn.OpenSessionWithHandler(sessionHandler, "")
+	if err != nil {
+		t.Fatalf("Got error while open session: %v", err)
+	}
+
+	if err = session.Create("table:messages", "key_format=S,value_format=S"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	if _, n := sessionHandler.counts(); n == 0 {
+		t.Error("Expected the session handler to receive messages")
+	}
+}
@@ -0,0 +1,158 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger -rdynamic
+#include <stdlib.h>
+#include <errno.h>
+#include <wiredtiger.h>
+
+static int wiredtiger_extension_config_get(WT_CONNECTION *connection, WT_SESSION *session, WT_CONFIG_ARG *config, const char *key, WT_CONFIG_ITEM *value) {
+	WT_EXTENSION_API *api = connection->get_extension_api(connection);
+
+	return api->config_get(api, session, config, key, value);
+}
+*/
+import "C"
+import (
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// Entry point WiredTiger resolves in the running program when an extension
+// is loaded from the "local" path.
+const goExtensionEntry = "wiredtiger_go_extension_init"
+
+// Extension is the initialization function of an extension implemented in
+// Go. It runs when WiredTiger loads the extension, which for entries in the
+// wiredtiger_open "extensions" list is before Open returns.
+type Extension func(conn *Connection, config *ExtensionConfig) error
+
+// ExtensionConfig is the configuration WiredTiger hands to an extension or
+// customization callback.
+type ExtensionConfig struct {
+	conn    *C.WT_CONNECTION
+	session *C.WT_SESSION
+	w       *C.WT_CONFIG_ARG
+}
+
+// Get returns the raw value of key, without surrounding quotes.
+func (c *ExtensionConfig) Get(key string) (string, error) {
+	var v C.WT_CONFIG_ITEM
+
+	if c == nil || c.w == nil {
+		return "", NewError(WT_NOTFOUND, nil)
+	}
+
+	keyC := C.CString(key)
+	defer C.free(unsafe.Pointer(keyC))
+
+	if res := int(C.wiredtiger_extension_config_get(c.conn, c.session, c.w, keyC, &v)); res != 0 {
+		return "", NewError(res, nil)
+	}
+
+	return C.GoStringN(v.str, C.int(v.len)), nil
+}
+
+// GetItem returns the parsed value of key, see ParseConfig.
+func (c *ExtensionConfig) GetItem(key string) (*ConfigItem, error) {
+	var v C.WT_CONFIG_ITEM
+
+	if c == nil || c.w == nil {
+		return nil, NewError(WT_NOTFOUND, nil)
+	}
+
+	keyC := C.CString(key)
+	defer C.free(unsafe.Pointer(keyC))
+
+	if res := int(C.wiredtiger_extension_config_get(c.conn, c.session, c.w, keyC, &v)); res != 0 {
+		return nil, NewError(res, nil)
+	}
+
+	return newConfigItem(&v)
+}
+
+var extensions = struct {
+	sync.RWMutex
+	m map[string]Extension
+}{m: make(map[string]Extension)}
+
+// RegisterExtension makes ext loadable under name, see ExtensionEntry.
+func RegisterExtension(name string, ext Extension) error {
+	if len(name) == 0 || ext == nil {
+		return NewError(EINVAL, nil)
+	}
+
+	extensions.Lock()
+	defer extensions.Unlock()
+
+	if _, ok := extensions.m[name]; ok {
+		return NewError(EINVAL, nil)
+	}
+
+	extensions.m[name] = ext
+	return nil
+}
+
+func unregisterExtension(name string) {
+	extensions.Lock()
+	delete(extensions.m, name)
+	extensions.Unlock()
+}
+
+// ExtensionEntry returns the entry of a wiredtiger_open "extensions" list
+// that loads the Go extension registered under name, e.g.
+//
+//	Open(home, "create,extensions=["+ExtensionEntry("mycoll", "")+"]")
+//
+// config is passed to the extension alongside its name.
+func ExtensionEntry(name, config string) string {
+	return "local=(" + goExtensionConfig(name, config) + ")"
+}
+
+func goExtensionConfig(name, config string) string {
+	var b strings.Builder
+
+	b.WriteString("entry=")
+	b.WriteString(goExtensionEntry)
+	b.WriteString(",config=(name=\"")
+	b.WriteString(name)
+	b.WriteString("\"")
+
+	if len(config) > 0 {
+		b.WriteString(",")
+		b.WriteString(config)
+	}
+
+	b.WriteString(")")
+
+	return b.String()
+}
+
+// LoadGoExtension runs the Go extension registered under name on an open
+// connection.
+func (c *Connection) LoadGoExtension(name, config string) error {
+	return c.LoadExtension("local", goExtensionConfig(name, config))
+}
+
+//export wiredtiger_go_extension_init
+func wiredtiger_go_extension_init(connection *C.WT_CONNECTION, config *C.WT_CONFIG_ARG) (res C.int) {
+	defer callbackRecover(&res)
+
+	cfg := &ExtensionConfig{conn: connection, w: config}
+
+	name, err := cfg.Get("name")
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	extensions.RLock()
+	ext := extensions.m[name]
+	extensions.RUnlock()
+
+	if ext == nil {
+		return C.int(C.ENOENT)
+	}
+
+	return callbackResult(ext(lookupConnection(connection), cfg))
+}
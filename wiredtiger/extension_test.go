@@ -0,0 +1,149 @@
+package wiredtiger
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestExtensionEntry(t *testing.T) {
+	for _, tc := range []struct {
+		name, config, expected string
+	}{
+		{"ext", "", `local=(entry=wiredtiger_go_extension_init,config=(name="ext"))`},
+		{"ext", "answer=42", `local=(entry=wiredtiger_go_extension_init,config=(name="ext",answer=42))`},
+	} {
+		if entry := ExtensionEntry(tc.name, tc.config); entry != tc.expected {
+			t.Errorf("ExtensionEntry(%q, %q) = %q, expected %q", tc.name, tc.config, entry, tc.expected)
+		}
+	}
+}
+
+func registerTestExtension(t *testing.T, name string, ext Extension) {
+	t.Helper()
+
+	if err := RegisterExtension(name, ext); err != nil {
+		t.Fatalf("Got error while register extension: %v", err)
+	}
+
+	t.Cleanup(func() { unregisterExtension(name) })
+}
+
+func TestRegisterExtension(t *testing.T) {
+	ext := func(conn *Connection, config *ExtensionConfig) error { return nil }
+
+	registerTestExtension(t, "test-register", ext)
+
+	if err := RegisterExtension("test-register", ext); err == nil {
+		t.Error("Expected an error for a name already registered")
+	}
+
+	if err := RegisterExtension("", ext); err == nil {
+		t.Error("Expected an error for an empty name")
+	}
+
+	if err := RegisterExtension("test-nil", nil); err == nil {
+		t.Error("Expected an error for a nil extension")
+	}
+}
+
+func TestGoExtension(t *testing.T) {
+	type call struct {
+		conn   *Connection
+		name   string
+		answer string
+		item   *ConfigItem
+	}
+
+	var calls []call
+
+	registerTestExtension(t, "test-load", func(conn *Connection, config *ExtensionConfig) error {
+		c := call{conn: conn}
+
+		var err error
+		if c.name, err = config.Get("name"); err != nil {
+			return err
+		}
+		if c.answer, err = config.Get("answer"); err != nil {
+			return err
+		}
+		if c.item, err = config.GetItem("answer"); err != nil {
+			return err
+		}
+
+		calls = append(calls, c)
+		return nil
+	})
+
+	conn, err := Open(t.TempDir(), "create,extensions=["+ExtensionEntry("test-load", "answer=42")+"]")
+	if err != nil {
+		t.Fatalf("Got error while open database: %v", err)
+	}
+	defer conn.Close("")
+
+	if err = conn.LoadGoExtension("test-load", "answer=7"); err != nil {
+		t.Fatalf("Got error while load extension: %v", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("Extension ran %d times, expected 2", len(calls))
+	}
+
+	for i, answer := range []int64{42, 7} {
+		c := calls[i]
+
+		if c.conn != conn {
+			t.Errorf("Call %d: got a connection other than the one Open returned", i+1)
+		}
+
+		if c.name != "test-load" || c.answer != strconv.FormatInt(answer, 10) {
+			t.Errorf("Call %d: got name %q and answer %q", i+1, c.name, c.answer)
+		}
+
+		if c.item == nil || c.item.Type != ConfigNum || c.item.Val != answer {
+			t.Errorf("Call %d: got item %+v, expected the number %d", i+1, c.item, answer)
+		}
+	}
+
+	if err = conn.LoadGoExtension("test-missing", ""); err == nil {
+		t.Error("Expected an error for an extension that is not registered")
+	}
+}
+
+func TestGoExtensionFailedOpen(t *testing.T) {
+	var seen *Connection
+
+	registerTestExtension(t, "test-fail", func(conn *Connection, config *ExtensionConfig) error {
+		seen = conn
+		return errors.New("refused")
+	})
+
+	registry.RLock()
+	before := len(registry.connections)
+	registry.RUnlock()
+
+	if _, err := Open(t.TempDir(), "create,extensions=["+ExtensionEntry("test-fail", "")+"]"); err == nil {
+		t.Fatal("Expected the open to fail")
+	}
+
+	if seen == nil {
+		t.Fatal("Expected the extension to run")
+	}
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	if len(registry.connections) != before {
+		t.Errorf("Got %d registered connections after the failed open, expected %d", len(registry.connections), before)
+	}
+
+	for _, c := range registry.connections {
+		if c == seen {
+			t.Error("Expected the failed open to drop the wrapper the extension saw")
+		}
+	}
+
+	if registry.opening {
+		t.Error("Expected the failed open to end the open")
+	}
+}
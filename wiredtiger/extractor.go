@@ -0,0 +1,115 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <stdint.h>
+#include <errno.h>
+#include <wiredtiger.h>
+
+typedef struct {
+	WT_EXTRACTOR iface;
+	uintptr_t handle;
+} GO_EXTRACTOR;
+
+extern int goExtractorExtract(uintptr_t handle, WT_SESSION *session, void *key, size_t key_size, void *value, size_t value_size, WT_CURSOR *result_cursor);
+extern void goExtractorTerminate(uintptr_t handle);
+
+static int wiredtiger_extractor_extract(WT_EXTRACTOR *extractor, WT_SESSION *session, const WT_ITEM *key, const WT_ITEM *value, WT_CURSOR *result_cursor) {
+	return goExtractorExtract(((GO_EXTRACTOR *)extractor)->handle, session, (void *)key->data, key->size, (void *)value->data, value->size, result_cursor);
+}
+
+static int wiredtiger_extractor_terminate(WT_EXTRACTOR *extractor, WT_SESSION *session) {
+	goExtractorTerminate(((GO_EXTRACTOR *)extractor)->handle);
+	free(extractor);
+
+	return 0;
+}
+
+static int wiredtiger_connection_add_extractor(WT_CONNECTION *connection, const char *name, uintptr_t handle, const char *config) {
+	GO_EXTRACTOR *e;
+	int ret;
+
+	if ((e = calloc(1, sizeof(GO_EXTRACTOR))) == NULL)
+		return ENOMEM;
+
+	e->iface.extract = wiredtiger_extractor_extract;
+	e->iface.terminate = wiredtiger_extractor_terminate;
+	e->handle = handle;
+
+	if ((ret = connection->add_extractor(connection, name, &e->iface, config)) != 0)
+		free(e);
+
+	return ret;
+}
+*/
+import "C"
+import (
+	"runtime/cgo"
+	"strings"
+	"unsafe"
+)
+
+// Extractor computes the keys of indexes created with "extractor=name".
+//
+// Extract receives the packed key and value of a table row and adds one
+// index entry per call to result.SetKey followed by result.Insert. Adding
+// no entries leaves the row out of the index. key and value are only valid
+// during the call, and Extract is called concurrently from WiredTiger's
+// threads.
+//
+// The index must be created with "key_format=u": each index key is a
+// []byte, ordered by its bytes. Keys made of several columns can be built
+// with Pack.
+type Extractor interface {
+	Extract(key, value []byte, result *Cursor) error
+}
+
+func (c *Connection) AddExtractor(name string, extractor Extractor) error {
+	var nameC *C.char = nil
+
+	if extractor == nil {
+		return NewError(EINVAL, nil)
+	}
+
+	if len(name) > 0 {
+		nameC = C.CString(name)
+		defer C.free(unsafe.Pointer(nameC))
+	}
+
+	h := cgo.NewHandle(extractor)
+
+	if res := int(C.wiredtiger_connection_add_extractor(c.w, nameC, C.uintptr_t(h), nil)); res != 0 {
+		h.Delete()
+		return NewError(res, nil)
+	}
+
+	return nil
+}
+
+//export goExtractorExtract
+func goExtractorExtract(handle C.uintptr_t, session *C.WT_SESSION, key unsafe.Pointer, keySize C.size_t, value unsafe.Pointer, valueSize C.size_t, resultCursor *C.WT_CURSOR) (res C.int) {
+	defer callbackRecover(&res)
+
+	// The cursor is WiredTiger's and is not in raw mode: its key is set as
+	// a WT_ITEM, which is how set_key takes a "u" column. WiredTiger pads
+	// the index key format with an "x" of its own.
+	if strings.TrimSuffix(C.GoString(resultCursor.key_format), "x") != "u" {
+		return C.int(C.EINVAL)
+	}
+
+	result := new(Cursor)
+	result.w = resultCursor
+	result.session = lookupSession(session)
+	result.uri = C.GoString(resultCursor.uri)
+	result.keyFormat = "u"
+	result.valueFormat = C.GoString(resultCursor.value_format)
+
+	e := cgo.Handle(handle).Value().(Extractor)
+	return callbackResult(e.Extract(borrowBytes(key, keySize), borrowBytes(value, valueSize), result))
+}
+
+//export goExtractorTerminate
+func goExtractorTerminate(handle C.uintptr_t) {
+	cgo.Handle(handle).Delete()
+}
@@ -0,0 +1,124 @@
+package wiredtiger
+
+import (
+	"strings"
+	"testing"
+)
+
+// tagExtractor indexes a row under each of the comma-separated tags of its
+// value.
+type tagExtractor struct{}
+
+func (tagExtractor) Extract(key, value []byte, result *Cursor) error {
+	var tags string
+
+	if err := UnPack(nil, "S", value, &tags); err != nil {
+		return err
+	}
+
+	for _, tag := range strings.Split(tags, ",") {
+		if len(tag) == 0 {
+			continue
+		}
+
+		if err := result.SetKey([]byte(tag)); err != nil {
+			return err
+		}
+
+		if err := result.Insert(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestExtractor(t *testing.T) {
+	conn, session := openTestSession(t, "")
+
+	if err := conn.AddExtractor("tags", tagExtractor{}); err != nil {
+		t.Fatalf("Got error while add extractor: %v", err)
+	}
+
+	if err := session.Create("table:posts", "key_format=S,value_format=S,columns=(id,tags)"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	if err := session.Create("index:posts:tags", "key_format=u,extractor=tags"); err != nil {
+		t.Fatalf("Got error while create index: %v", err)
+	}
+
+	c, err := session.OpenCursor("table:posts", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+	defer c.Close()
+
+	for _, row := range [][2]string{{"p1", "go,db"}, {"p2", "db"}, {"p3", ""}} {
+		c.SetKey(row[0])
+		c.SetValue(row[1])
+		if err = c.Insert(); err != nil {
+			t.Fatalf("Got error while insert: %v", err)
+		}
+	}
+
+	// Removing a row removes the entries extracted from it.
+	c.SetKey("p4")
+	c.SetValue("db,web")
+	if err = c.Insert(); err != nil {
+		t.Fatalf("Got error while insert: %v", err)
+	}
+
+	c.SetKey("p4")
+	if err = c.Remove(); err != nil {
+		t.Fatalf("Got error while remove: %v", err)
+	}
+
+	index, err := session.OpenCursor("index:posts:tags(id)", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open index cursor: %v", err)
+	}
+	defer index.Close()
+
+	var entries []string
+
+	for index.Next() == nil {
+		var tag []byte
+		var id string
+
+		if err = index.GetKey(&tag); err != nil {
+			t.Fatalf("Got error while get index key: %v", err)
+		}
+
+		if err = index.GetValue(&id); err != nil {
+			t.Fatalf("Got error while get index value: %v", err)
+		}
+
+		entries = append(entries, string(tag)+"="+id)
+	}
+
+	if got, expected := strings.Join(entries, ","), "db=p1,db=p2,go=p1"; got != expected {
+		t.Errorf("Got index entries %q, expected %q", got, expected)
+	}
+
+	// Only "u" index keys can be handed over.
+	if err = session.Create("table:bad", "key_format=S,value_format=S,columns=(id,tags)"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	if err = session.Create("index:bad:tags", "key_format=S,extractor=tags"); err != nil {
+		t.Fatalf("Got error while create index: %v", err)
+	}
+
+	bad, err := session.OpenCursor("table:bad", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+	defer bad.Close()
+
+	bad.SetKey("p1")
+	bad.SetValue("go")
+	if err = bad.Insert(); err == nil {
+		t.Error("Expected an error for an index key_format other than u")
+	}
+}
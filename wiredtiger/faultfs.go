@@ -0,0 +1,169 @@
+package wiredtiger
+
+import (
+	"sync"
+)
+
+type FaultOp int
+
+const (
+	FaultOpen FaultOp = iota
+	FaultRead
+	FaultWrite
+	FaultSync
+	FaultTruncate
+	FaultRemove
+	FaultRename
+)
+
+func (op FaultOp) String() string {
+	switch op {
+	case FaultOpen:
+		return "open"
+	case FaultRead:
+		return "read"
+	case FaultWrite:
+		return "write"
+	case FaultSync:
+		return "sync"
+	case FaultTruncate:
+		return "truncate"
+	case FaultRemove:
+		return "remove"
+	case FaultRename:
+		return "rename"
+	}
+
+	return "unknown"
+}
+
+// FaultFunc decides whether an operation on the named file fails. Offset
+// and length describe reads and writes; for truncate, offset is the new
+// size. Returning a non-nil error fails the operation without performing it,
+// except for a *TornWrite, which writes a prefix of the data first.
+type FaultFunc func(op FaultOp, name string, offset int64, length int) error
+
+// TornWrite simulates a write interrupted part way: the first N bytes reach
+// the file before the write fails with Err.
+type TornWrite struct {
+	N   int
+	Err error
+}
+
+func (e *TornWrite) Error() string {
+	return "wiredtiger: torn write: " + e.Err.Error()
+}
+
+func (e *TornWrite) Unwrap() error {
+	return e.Err
+}
+
+// FaultFileSystem wraps a FileSystem and fails operations chosen by a
+// FaultFunc, to test how the application copes with I/O errors. Use
+// syscall.ENOSPC or syscall.EIO as the error to have WiredTiger see the
+// corresponding error number.
+type FaultFileSystem struct {
+	FileSystem
+
+	mu    sync.RWMutex
+	fault FaultFunc
+}
+
+// NewFaultFileSystem returns fs wrapped with fault, which may be nil to
+// inject nothing until SetFault is called.
+func NewFaultFileSystem(fs FileSystem, fault FaultFunc) *FaultFileSystem {
+	return &FaultFileSystem{FileSystem: fs, fault: fault}
+}
+
+// SetFault replaces the fault function; nil stops injecting faults.
+func (fs *FaultFileSystem) SetFault(fault FaultFunc) {
+	fs.mu.Lock()
+	fs.fault = fault
+	fs.mu.Unlock()
+}
+
+func (fs *FaultFileSystem) check(op FaultOp, name string, offset int64, length int) error {
+	fs.mu.RLock()
+	fault := fs.fault
+	fs.mu.RUnlock()
+
+	if fault == nil {
+		return nil
+	}
+
+	return fault(op, name, offset, length)
+}
+
+func (fs *FaultFileSystem) Open(name string, fileType FileType, flags FileOpenFlags) (File, error) {
+	if err := fs.check(FaultOpen, name, 0, 0); err != nil {
+		return nil, err
+	}
+
+	f, err := fs.FileSystem.Open(name, fileType, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &faultFile{File: f, fs: fs, name: name}, nil
+}
+
+func (fs *FaultFileSystem) Remove(name string) error {
+	if err := fs.check(FaultRemove, name, 0, 0); err != nil {
+		return err
+	}
+
+	return fs.FileSystem.Remove(name)
+}
+
+func (fs *FaultFileSystem) Rename(from, to string) error {
+	if err := fs.check(FaultRename, from, 0, 0); err != nil {
+		return err
+	}
+
+	return fs.FileSystem.Rename(from, to)
+}
+
+type faultFile struct {
+	File
+	fs   *FaultFileSystem
+	name string
+}
+
+func (f *faultFile) ReadAt(p []byte, off int64) (int, error) {
+	if err := f.fs.check(FaultRead, f.name, off, len(p)); err != nil {
+		return 0, err
+	}
+
+	return f.File.ReadAt(p, off)
+}
+
+func (f *faultFile) WriteAt(p []byte, off int64) (int, error) {
+	if err := f.fs.check(FaultWrite, f.name, off, len(p)); err != nil {
+		if torn, ok := err.(*TornWrite); ok && torn.N > 0 {
+			n, werr := f.File.WriteAt(p[:min(torn.N, len(p))], off)
+			if werr != nil {
+				return n, werr
+			}
+			return n, torn
+		}
+		return 0, err
+	}
+
+	return f.File.WriteAt(p, off)
+}
+
+func (f *faultFile) Sync() error {
+	if err := f.fs.check(FaultSync, f.name, 0, 0); err != nil {
+		return err
+	}
+
+	return f.File.Sync()
+}
+
+func (f *faultFile) Truncate(size int64) error {
+	if err := f.fs.check(FaultTruncate, f.name, size, 0); err != nil {
+		return err
+	}
+
+	return f.File.Truncate(size)
+}
@@ -0,0 +1,474 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <stdint.h>
+#include <stdbool.h>
+#include <string.h>
+#include <errno.h>
+#include <wiredtiger.h>
+
+typedef struct {
+	WT_FILE_SYSTEM iface;
+	uintptr_t handle;
+} GO_FILE_SYSTEM;
+
+typedef struct {
+	WT_FILE_HANDLE iface;
+	uintptr_t handle;
+} GO_FILE_HANDLE;
+
+extern int goFileSystemDirectoryList(uintptr_t handle, char *directory, char *prefix, int single, char ***dirlist, uint32_t *countp);
+extern int goFileSystemExist(uintptr_t handle, char *name, int *existp);
+extern int goFileSystemOpenFile(uintptr_t handle, char *name, int file_type, uint32_t flags, uintptr_t *file_handle);
+extern int goFileSystemRemove(uintptr_t handle, char *name, uint32_t flags);
+extern int goFileSystemRename(uintptr_t handle, char *from, char *to, uint32_t flags);
+extern int goFileSystemSize(uintptr_t handle, char *name, int64_t *sizep);
+extern void goFileSystemTerminate(uintptr_t handle);
+
+extern int goFileHandleClose(uintptr_t handle);
+extern int goFileHandleLock(uintptr_t handle, int lock);
+extern int goFileHandleRead(uintptr_t handle, int64_t offset, size_t len, void *buf);
+extern int goFileHandleSize(uintptr_t handle, int64_t *sizep);
+extern int goFileHandleSync(uintptr_t handle);
+extern int goFileHandleTruncate(uintptr_t handle, int64_t len);
+extern int goFileHandleWrite(uintptr_t handle, int64_t offset, size_t len, void *buf);
+
+static int wiredtiger_fh_close(WT_FILE_HANDLE *file_handle, WT_SESSION *session) {
+	int ret;
+
+	ret = goFileHandleClose(((GO_FILE_HANDLE *)file_handle)->handle);
+	free(file_handle->name);
+	free(file_handle);
+
+	return ret;
+}
+
+static int wiredtiger_fh_lock(WT_FILE_HANDLE *file_handle, WT_SESSION *session, bool lock) {
+	return goFileHandleLock(((GO_FILE_HANDLE *)file_handle)->handle, lock);
+}
+
+static int wiredtiger_fh_read(WT_FILE_HANDLE *file_handle, WT_SESSION *session, wt_off_t offset, size_t len, void *buf) {
+	return goFileHandleRead(((GO_FILE_HANDLE *)file_handle)->handle, offset, len, buf);
+}
+
+static int wiredtiger_fh_size(WT_FILE_HANDLE *file_handle, WT_SESSION *session, wt_off_t *sizep) {
+	return goFileHandleSize(((GO_FILE_HANDLE *)file_handle)->handle, sizep);
+}
+
+static int wiredtiger_fh_sync(WT_FILE_HANDLE *file_handle, WT_SESSION *session) {
+	return goFileHandleSync(((GO_FILE_HANDLE *)file_handle)->handle);
+}
+
+static int wiredtiger_fh_truncate(WT_FILE_HANDLE *file_handle, WT_SESSION *session, wt_off_t len) {
+	return goFileHandleTruncate(((GO_FILE_HANDLE *)file_handle)->handle, len);
+}
+
+static int wiredtiger_fh_write(WT_FILE_HANDLE *file_handle, WT_SESSION *session, wt_off_t offset, size_t len, const void *buf) {
+	return goFileHandleWrite(((GO_FILE_HANDLE *)file_handle)->handle, offset, len, (void *)buf);
+}
+
+static int wiredtiger_fs_directory_list(WT_FILE_SYSTEM *file_system, WT_SESSION *session, const char *directory, const char *prefix, char ***dirlistp, uint32_t *countp) {
+	return goFileSystemDirectoryList(((GO_FILE_SYSTEM *)file_system)->handle, (char *)directory, (char *)prefix, 0, dirlistp, countp);
+}
+
+static int wiredtiger_fs_directory_list_single(WT_FILE_SYSTEM *file_system, WT_SESSION *session, const char *directory, const char *prefix, char ***dirlistp, uint32_t *countp) {
+	return goFileSystemDirectoryList(((GO_FILE_SYSTEM *)file_system)->handle, (char *)directory, (char *)prefix, 1, dirlistp, countp);
+}
+
+static int wiredtiger_fs_directory_list_free(WT_FILE_SYSTEM *file_system, WT_SESSION *session, char **dirlist, uint32_t count) {
+	uint32_t i;
+
+	if (dirlist != NULL) {
+		for (i = 0; i < count; i++)
+			free(dirlist[i]);
+		free(dirlist);
+	}
+
+	return 0;
+}
+
+static int wiredtiger_fs_exist(WT_FILE_SYSTEM *file_system, WT_SESSION *session, const char *name, bool *existp) {
+	int exist = 0, ret;
+
+	ret = goFileSystemExist(((GO_FILE_SYSTEM *)file_system)->handle, (char *)name, &exist);
+	*existp = exist != 0;
+
+	return ret;
+}
+
+static int wiredtiger_fs_open_file(WT_FILE_SYSTEM *file_system, WT_SESSION *session, const char *name, WT_FS_OPEN_FILE_TYPE file_type, uint32_t flags, WT_FILE_HANDLE **file_handlep) {
+	GO_FILE_HANDLE *fh;
+	uintptr_t handle = 0;
+	int ret;
+
+	if ((ret = goFileSystemOpenFile(((GO_FILE_SYSTEM *)file_system)->handle, (char *)name, file_type, flags, &handle)) != 0)
+		return ret;
+
+	if ((fh = calloc(1, sizeof(GO_FILE_HANDLE))) == NULL || (fh->iface.name = strdup(name)) == NULL) {
+		free(fh);
+		goFileHandleClose(handle);
+		return ENOMEM;
+	}
+
+	fh->iface.file_system = file_system;
+	fh->iface.close = wiredtiger_fh_close;
+	fh->iface.fh_lock = wiredtiger_fh_lock;
+	fh->iface.fh_read = wiredtiger_fh_read;
+	fh->iface.fh_size = wiredtiger_fh_size;
+	fh->iface.fh_sync = wiredtiger_fh_sync;
+	fh->iface.fh_truncate = wiredtiger_fh_truncate;
+	fh->iface.fh_write = wiredtiger_fh_write;
+	fh->handle = handle;
+
+	*file_handlep = &fh->iface;
+	return 0;
+}
+
+static int wiredtiger_fs_remove(WT_FILE_SYSTEM *file_system, WT_SESSION *session, const char *name, uint32_t flags) {
+	return goFileSystemRemove(((GO_FILE_SYSTEM *)file_system)->handle, (char *)name, flags);
+}
+
+static int wiredtiger_fs_rename(WT_FILE_SYSTEM *file_system, WT_SESSION *session, const char *from, const char *to, uint32_t flags) {
+	return goFileSystemRename(((GO_FILE_SYSTEM *)file_system)->handle, (char *)from, (char *)to, flags);
+}
+
+static int wiredtiger_fs_size(WT_FILE_SYSTEM *file_system, WT_SESSION *session, const char *name, wt_off_t *sizep) {
+	return goFileSystemSize(((GO_FILE_SYSTEM *)file_system)->handle, (char *)name, sizep);
+}
+
+static int wiredtiger_fs_terminate(WT_FILE_SYSTEM *file_system, WT_SESSION *session) {
+	goFileSystemTerminate(((GO_FILE_SYSTEM *)file_system)->handle);
+	free(file_system);
+
+	return 0;
+}
+
+static int wiredtiger_connection_set_file_system(WT_CONNECTION *connection, uintptr_t handle, const char *config) {
+	GO_FILE_SYSTEM *fs;
+	int ret;
+
+	if ((fs = calloc(1, sizeof(GO_FILE_SYSTEM))) == NULL)
+		return ENOMEM;
+
+	fs->iface.fs_directory_list = wiredtiger_fs_directory_list;
+	fs->iface.fs_directory_list_single = wiredtiger_fs_directory_list_single;
+	fs->iface.fs_directory_list_free = wiredtiger_fs_directory_list_free;
+	fs->iface.fs_exist = wiredtiger_fs_exist;
+	fs->iface.fs_open_file = wiredtiger_fs_open_file;
+	fs->iface.fs_remove = wiredtiger_fs_remove;
+	fs->iface.fs_rename = wiredtiger_fs_rename;
+	fs->iface.fs_size = wiredtiger_fs_size;
+	fs->iface.terminate = wiredtiger_fs_terminate;
+	fs->handle = handle;
+
+	if ((ret = connection->set_file_system(connection, &fs->iface, config)) != 0)
+		free(fs);
+
+	return ret;
+}
+*/
+import "C"
+import (
+	"fmt"
+	"io"
+	"runtime/cgo"
+	"strings"
+	"sync/atomic"
+	"unsafe"
+)
+
+type FileType int
+
+const (
+	FileTypeCheckpoint = FileType(C.WT_FS_OPEN_FILE_TYPE_CHECKPOINT)
+	FileTypeData       = FileType(C.WT_FS_OPEN_FILE_TYPE_DATA)
+	FileTypeDirectory  = FileType(C.WT_FS_OPEN_FILE_TYPE_DIRECTORY)
+	FileTypeLog        = FileType(C.WT_FS_OPEN_FILE_TYPE_LOG)
+	FileTypeRegular    = FileType(C.WT_FS_OPEN_FILE_TYPE_REGULAR)
+)
+
+type FileOpenFlags uint32
+
+const (
+	FileOpenCreate    = FileOpenFlags(C.WT_FS_OPEN_CREATE)
+	FileOpenDirectIO  = FileOpenFlags(C.WT_FS_OPEN_DIRECTIO)
+	FileOpenDurable   = FileOpenFlags(C.WT_FS_OPEN_DURABLE)
+	FileOpenExclusive = FileOpenFlags(C.WT_FS_OPEN_EXCLUSIVE)
+	FileOpenFixed     = FileOpenFlags(C.WT_FS_OPEN_FIXED)
+	FileOpenReadOnly  = FileOpenFlags(C.WT_FS_OPEN_READONLY)
+)
+
+// FileSystem replaces the operating system calls WiredTiger uses for the
+// files of a connection, see OpenWithFileSystem. Names are the paths
+// WiredTiger builds from the home directory.
+//
+// Errors wrapping a syscall.Errno, such as those of the os package, are
+// reported to WiredTiger with that error number; WiredTiger relies on
+// ENOENT for files that do not exist.
+type FileSystem interface {
+	Open(name string, fileType FileType, flags FileOpenFlags) (File, error)
+	Exist(name string) (bool, error)
+	Remove(name string) error
+	Rename(from, to string) error
+	Size(name string) (int64, error)
+	// List returns the names, relative to directory, of the files in
+	// directory starting with prefix.
+	List(directory, prefix string) ([]string, error)
+}
+
+// File is a file opened by a FileSystem. ReadAt and WriteAt follow the
+// io.ReaderAt and io.WriterAt contracts; WiredTiger treats short reads and
+// writes as errors. Lock takes (true) or releases (false) an advisory lock
+// on the file.
+type File interface {
+	io.ReaderAt
+	io.WriterAt
+	Size() (int64, error)
+	Sync() error
+	Truncate(size int64) error
+	Lock(lock bool) error
+	Close() error
+}
+
+var fileSystemSeq uint64
+
+// OpenWithFileSystem opens a connection whose files are all accessed
+// through fs.
+func OpenWithFileSystem(home string, fs FileSystem, config string) (*Connection, error) {
+	return openWithFileSystem(home, nil, fs, config)
+}
+
+func openWithFileSystem(home string, handler EventHandler, fs FileSystem, config string) (*Connection, error) {
+	if fs == nil {
+		return nil, NewError(EINVAL, nil)
+	}
+
+	name := fmt.Sprintf("__go_file_system_%d", atomic.AddUint64(&fileSystemSeq, 1))
+	ext := func(conn *Connection, config *ExtensionConfig) error {
+		return conn.setFileSystem(fs)
+	}
+
+	if err := RegisterExtension(name, ext); err != nil {
+		return nil, err
+	}
+	defer unregisterExtension(name)
+
+	// The file system must be in place before WiredTiger touches the home
+	// directory, hence early_load. A later "extensions" key replaces an
+	// earlier one, so the application's extensions are carried over.
+	entries := "local=(" + goExtensionConfig(name, "") + ",early_load=true)"
+
+	if cfg, err := ParseConfig(config); err != nil {
+		return nil, err
+	} else if ext, err := cfg.Get("extensions"); err == nil && len(ext.Keys()) > 0 {
+		entries = strings.TrimSuffix(strings.TrimPrefix(ext.Str, "["), "]") + "," + entries
+	}
+
+	if len(config) > 0 {
+		config += ","
+	}
+
+	return OpenWithHandler(home, handler, config+"extensions=["+entries+"]")
+}
+
+func (c *Connection) setFileSystem(fs FileSystem) error {
+	h := cgo.NewHandle(fs)
+
+	if res := int(C.wiredtiger_connection_set_file_system(c.w, C.uintptr_t(h), nil)); res != 0 {
+		h.Delete()
+		return NewError(res, nil)
+	}
+
+	return nil
+}
+
+//export goFileSystemDirectoryList
+func goFileSystemDirectoryList(handle C.uintptr_t, directory *C.char, prefix *C.char, single C.int, dirlist ***C.char, count *C.uint32_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	*dirlist = nil
+	*count = 0
+
+	fs := cgo.Handle(handle).Value().(FileSystem)
+
+	names, err := fs.List(C.GoString(directory), C.GoString(prefix))
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	if single != 0 && len(names) > 1 {
+		names = names[:1]
+	}
+
+	if len(names) == 0 {
+		return 0
+	}
+
+	p := (**C.char)(C.calloc(C.size_t(len(names)), C.size_t(unsafe.Sizeof((*C.char)(nil)))))
+	if p == nil {
+		return C.ENOMEM
+	}
+
+	list := unsafe.Slice(p, len(names))
+
+	for i, name := range names {
+		list[i] = C.CString(name)
+	}
+
+	*dirlist = &list[0]
+	*count = C.uint32_t(len(names))
+
+	return 0
+}
+
+//export goFileSystemExist
+func goFileSystemExist(handle C.uintptr_t, name *C.char, exist *C.int) (res C.int) {
+	defer callbackRecover(&res)
+
+	fs := cgo.Handle(handle).Value().(FileSystem)
+
+	ok, err := fs.Exist(C.GoString(name))
+	if ok {
+		*exist = 1
+	}
+
+	return callbackResult(err)
+}
+
+//export goFileSystemOpenFile
+func goFileSystemOpenFile(handle C.uintptr_t, name *C.char, fileType C.int, flags C.uint32_t, fileHandle *C.uintptr_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	fs := cgo.Handle(handle).Value().(FileSystem)
+
+	f, err := fs.Open(C.GoString(name), FileType(fileType), FileOpenFlags(flags))
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	*fileHandle = C.uintptr_t(cgo.NewHandle(f))
+	return 0
+}
+
+//export goFileSystemRemove
+func goFileSystemRemove(handle C.uintptr_t, name *C.char, flags C.uint32_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	fs := cgo.Handle(handle).Value().(FileSystem)
+	return callbackResult(fs.Remove(C.GoString(name)))
+}
+
+//export goFileSystemRename
+func goFileSystemRename(handle C.uintptr_t, from *C.char, to *C.char, flags C.uint32_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	fs := cgo.Handle(handle).Value().(FileSystem)
+	return callbackResult(fs.Rename(C.GoString(from), C.GoString(to)))
+}
+
+//export goFileSystemSize
+func goFileSystemSize(handle C.uintptr_t, name *C.char, size *C.int64_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	fs := cgo.Handle(handle).Value().(FileSystem)
+
+	n, err := fs.Size(C.GoString(name))
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	*size = C.int64_t(n)
+	return 0
+}
+
+//export goFileSystemTerminate
+func goFileSystemTerminate(handle C.uintptr_t) {
+	cgo.Handle(handle).Delete()
+}
+
+//export goFileHandleClose
+func goFileHandleClose(handle C.uintptr_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	h := cgo.Handle(handle)
+	defer h.Delete()
+
+	return callbackResult(h.Value().(File).Close())
+}
+
+//export goFileHandleLock
+func goFileHandleLock(handle C.uintptr_t, lock C.int) (res C.int) {
+	defer callbackRecover(&res)
+
+	f := cgo.Handle(handle).Value().(File)
+	return callbackResult(f.Lock(lock != 0))
+}
+
+//export goFileHandleRead
+func goFileHandleRead(handle C.uintptr_t, offset C.int64_t, length C.size_t, buf unsafe.Pointer) (res C.int) {
+	defer callbackRecover(&res)
+
+	f := cgo.Handle(handle).Value().(File)
+
+	n, err := f.ReadAt(borrowBytes(buf, length), int64(offset))
+	if n == int(length) {
+		return 0
+	}
+
+	if err == nil || err == io.EOF {
+		return C.int(WT_ERROR)
+	}
+
+	return callbackResult(err)
+}
+
+//export goFileHandleSize
+func goFileHandleSize(handle C.uintptr_t, size *C.int64_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	f := cgo.Handle(handle).Value().(File)
+
+	n, err := f.Size()
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	*size = C.int64_t(n)
+	return 0
+}
+
+//export goFileHandleSync
+func goFileHandleSync(handle C.uintptr_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	f := cgo.Handle(handle).Value().(File)
+	return callbackResult(f.Sync())
+}
+
+//export goFileHandleTruncate
+func goFileHandleTruncate(handle C.uintptr_t, length C.int64_t) (res C.int) {
+	defer callbackRecover(&res)
+
+	f := cgo.Handle(handle).Value().(File)
+	return callbackResult(f.Truncate(int64(length)))
+}
+
+//export goFileHandleWrite
+func goFileHandleWrite(handle C.uintptr_t, offset C.int64_t, length C.size_t, buf unsafe.Pointer) (res C.int) {
+	defer callbackRecover(&res)
+
+	f := cgo.Handle(handle).Value().(File)
+
+	n, err := f.WriteAt(borrowBytes(buf, length), int64(offset))
+	if err != nil {
+		return callbackResult(err)
+	}
+
+	if n != int(length) {
+		return C.int(WT_ERROR)
+	}
+
+	return 0
+}
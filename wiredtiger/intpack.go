@@ -134,13 +134,17 @@ func vpack_int(buf []byte, x int64) []byte {
 }
 
 func vunpack_uint(buf []byte, bcur *int, bend int) (uint64, int) {
+	if *bcur >= bend {
+		return 0, EINVAL
+	}
+
 	switch buf[*bcur] & 0xF0 {
 	case iPOS_1BYTE_MARKER, iPOS_1BYTE_MARKER | 0x10, iPOS_1BYTE_MARKER | 0x20, iPOS_1BYTE_MARKER | 0x30:
 		x := get_bits(uint64(buf[*bcur]), 6, 0)
 		*bcur++
 		return x, 0
 	case iPOS_2BYTE_MARKER, iPOS_2BYTE_MARKER | 0x10:
-		if *bcur < bend {
+		if *bcur+1 < bend {
 			x := get_bits(uint64(buf[*bcur]), 5, 0) << 8
 			*bcur++
 			x |= uint64(buf[*bcur])
@@ -162,6 +166,10 @@ func vunpack_uint(buf []byte, bcur *int, bend int) (uint64, int) {
 }
 
 func vunpack_int(buf []byte, bcur *int, bend int) (int64, int) {
+	if *bcur >= bend {
+		return 0, EINVAL
+	}
+
 	switch buf[*bcur] & 0xF0 {
 	case iNEG_MULTI_MARKER:
 		x, r := vunpack_negint(buf, bcur, bend)
@@ -169,7 +177,7 @@ func vunpack_int(buf []byte, bcur *int, bend int) (int64, int) {
 		return int64(x), r
 
 	case iNEG_2BYTE_MARKER, iNEG_2BYTE_MARKER | 0x10:
-		if *bcur < bend {
+		if *bcur+1 < bend {
 			x := int64(get_bits(uint64(buf[*bcur]), 5, 0) << 8)
 			*bcur++
 			x |= int64(buf[*bcur])
@@ -0,0 +1,206 @@
+package wiredtiger
+
+import (
+	"bytes"
+	"iter"
+)
+
+// Iterators
+//
+// The sequences yield the cursor itself, positioned on each record in turn,
+// so the loop body reads it with GetKey and GetValue or their variants. A
+// failure is yielded once as a non-nil error, after which the sequence ends;
+// running off the end of the data is not an error. The cursor is reset when
+// the loop finishes.
+//
+//	for c, err := range cursor.Range(int64(10), int64(20)) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Bounds are compared with the packed keys byte by byte, which matches the
+// table's order unless it uses a custom collator.
+
+// All iterates over every record in key order.
+func (c *Cursor) All() iter.Seq2[*Cursor, error] {
+	return c.scan(scanBounds{}, false)
+}
+
+// Reverse iterates over every record in reverse key order.
+func (c *Cursor) Reverse() iter.Seq2[*Cursor, error] {
+	return c.scan(scanBounds{}, true)
+}
+
+// Range iterates in key order over the records with keys from lo up to but
+// excluding hi. A nil bound leaves that end open; a composite key is given
+// as a []interface{} holding its columns.
+func (c *Cursor) Range(lo, hi interface{}) iter.Seq2[*Cursor, error] {
+	return c.rangeOf(lo, hi, false, false)
+}
+
+// RangeClosed is Range with hi included.
+func (c *Cursor) RangeClosed(lo, hi interface{}) iter.Seq2[*Cursor, error] {
+	return c.rangeOf(lo, hi, true, false)
+}
+
+// ReverseRange iterates over the records of Range(lo, hi) in reverse key
+// order, from the last key below hi down to lo.
+func (c *Cursor) ReverseRange(lo, hi interface{}) iter.Seq2[*Cursor, error] {
+	return c.rangeOf(lo, hi, false, true)
+}
+
+// Prefix iterates in key order over the records whose packed key starts
+// with prefix. For a "u" key format the packed key is the byte slice itself.
+// A packed "S" key is the string followed by a NUL byte, so a string prefix
+// is given as its bytes without the terminator: []byte("user:") matches
+// "user:1", while the packed form of "user:" matches only "user:" itself.
+func (c *Cursor) Prefix(prefix []byte) iter.Seq2[*Cursor, error] {
+	if len(prefix) == 0 {
+		return c.All()
+	}
+
+	p := append([]byte{}, prefix...)
+
+	return c.scan(scanBounds{lo: p, hi: prefixEnd(p)}, false)
+}
+
+func (c *Cursor) rangeOf(lo, hi interface{}, closed, reverse bool) iter.Seq2[*Cursor, error] {
+	var b scanBounds
+	var err error
+
+	if lo != nil {
+		b.lo, err = c.packBound(lo)
+	}
+
+	if hi != nil && err == nil {
+		b.hi, err = c.packBound(hi)
+		b.closed = closed
+	}
+
+	if err != nil {
+		return func(yield func(*Cursor, error) bool) {
+			yield(nil, err)
+		}
+	}
+
+	return c.scan(b, reverse)
+}
+
+func (c *Cursor) packBound(v interface{}) ([]byte, error) {
+	if a, ok := v.([]interface{}); ok {
+		return Pack(c.session, c.keyFormat, nil, a...)
+	}
+
+	return Pack(c.session, c.keyFormat, nil, v)
+}
+
+// prefixEnd returns the first key after every key starting with prefix, or
+// nil if there is none because the prefix is all 0xff bytes.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
+
+// scanBounds holds packed bounds; a nil bound is open. lo is always
+// included and hi only if closed.
+type scanBounds struct {
+	lo, hi []byte
+	closed bool
+}
+
+func (b scanBounds) below(key []byte) bool {
+	return b.lo != nil && bytes.Compare(key, b.lo) < 0
+}
+
+func (b scanBounds) above(key []byte) bool {
+	if b.hi == nil {
+		return false
+	}
+
+	if b.closed {
+		return bytes.Compare(key, b.hi) > 0
+	}
+
+	return bytes.Compare(key, b.hi) >= 0
+}
+
+// scan steps through the records within b, starting from the bound the
+// direction begins at, or from the first or last record without one.
+func (c *Cursor) scan(b scanBounds, reverse bool) iter.Seq2[*Cursor, error] {
+	return func(yield func(*Cursor, error) bool) {
+		var err error
+
+		defer c.Reset()
+
+		if err = c.Reset(); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		step, start, past := c.Next, b.lo, b.above
+		if reverse {
+			step, start, past = c.Prev, b.hi, b.below
+		}
+
+		if start != nil {
+			// Positioned as a raw key: the bound is already packed.
+			c.keyPack = append(c.keyPack[:0], start...)
+			c.keySetExt = true
+
+			// Land on the first key not less than the bound going forward,
+			// or the last key not greater than it going back.
+			var exact int
+			if exact, err = c.SearchNear(); err == nil {
+				if !reverse && exact < 0 {
+					err = c.Next()
+				} else if reverse && exact > 0 {
+					err = c.Prev()
+				}
+			}
+		} else {
+			err = step()
+		}
+
+		for {
+			var key []byte
+
+			if err == nil {
+				key, err = c.rawKey()
+			}
+
+			if err != nil {
+				if !IsNotFoundErr(err) {
+					yield(nil, err)
+				}
+				return
+			}
+
+			// Going back from an excluded upper bound the first record can
+			// be the bound itself.
+			if reverse && b.above(key) {
+				err = step()
+				continue
+			}
+
+			if past(key) {
+				return
+			}
+
+			if !yield(c, nil) {
+				return
+			}
+
+			err = step()
+		}
+	}
+}
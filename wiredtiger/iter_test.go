@@ -0,0 +1,203 @@
+package wiredtiger
+
+import (
+	"bytes"
+	"iter"
+	"testing"
+)
+
+func TestPrefixEnd(t *testing.T) {
+	for _, tc := range []struct {
+		prefix, end []byte
+	}{
+		{[]byte("ab"), []byte("ac")},
+		{[]byte{'a', 0xff}, []byte{'b'}},
+		{[]byte{0x01, 0xff, 0xff}, []byte{0x02}},
+		{[]byte{0xff}, nil},
+		{[]byte{0xff, 0xff, 0xff}, nil},
+	} {
+		if end := prefixEnd(tc.prefix); !bytes.Equal(end, tc.end) {
+			t.Errorf("prefixEnd(% x) = % x, expected % x", tc.prefix, end, tc.end)
+		}
+	}
+}
+
+func TestScanBounds(t *testing.T) {
+	open := scanBounds{lo: []byte("b"), hi: []byte("d")}
+	closed := scanBounds{lo: []byte("b"), hi: []byte("d"), closed: true}
+
+	for _, tc := range []struct {
+		b            scanBounds
+		key          string
+		below, above bool
+	}{
+		{open, "a", true, false},
+		{open, "b", false, false},
+		{open, "c", false, false},
+		{open, "d", false, true},
+		{closed, "d", false, false},
+		{closed, "d\x00", false, true},
+		{scanBounds{}, "z", false, false},
+	} {
+		if below := tc.b.below([]byte(tc.key)); below != tc.below {
+			t.Errorf("%+v below(%q) = %v", tc.b, tc.key, below)
+		}
+
+		if above := tc.b.above([]byte(tc.key)); above != tc.above {
+			t.Errorf("%+v above(%q) = %v", tc.b, tc.key, above)
+		}
+	}
+}
+
+func openIterTestCursor(t *testing.T, keys ...string) *Cursor {
+	_, session := openTestSession(t, "")
+
+	if err := session.Create("table:iter", "key_format=u,value_format=u"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	c, err := session.OpenCursor("table:iter", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	for _, k := range keys {
+		if err = c.SetKey([]byte(k)); err == nil {
+			if err = c.SetValue([]byte{}); err == nil {
+				err = c.Insert()
+			}
+		}
+
+		if err != nil {
+			t.Fatalf("Got error while insert %q: %v", k, err)
+		}
+	}
+
+	return c
+}
+
+func collectKeys(t *testing.T, seq iter.Seq2[*Cursor, error]) []string {
+	var keys []string
+
+	for c, err := range seq {
+		if err != nil {
+			t.Fatalf("Got error while iterating: %v", err)
+		}
+
+		var k []byte
+		if err = c.GetKey(&k); err != nil {
+			t.Fatalf("Got error while get key: %v", err)
+		}
+
+		keys = append(keys, string(k))
+	}
+
+	return keys
+}
+
+func expectKeys(t *testing.T, name string, got []string, expected ...string) {
+	t.Helper()
+
+	if len(got) != len(expected) {
+		t.Errorf("%s returned %q, expected %q", name, got, expected)
+		return
+	}
+
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Errorf("%s returned %q, expected %q", name, got, expected)
+			return
+		}
+	}
+}
+
+func TestCursorIterators(t *testing.T) {
+	c := openIterTestCursor(t, "a", "b", "ba", "c", "d", "\xff", "\xff\xff", "\xff\xff\x01")
+
+	expectKeys(t, "Prefix(empty)", collectKeys(t, c.Prefix(nil)),
+		"a", "b", "ba", "c", "d", "\xff", "\xff\xff", "\xff\xff\x01")
+	expectKeys(t, "Prefix(b)", collectKeys(t, c.Prefix([]byte("b"))), "b", "ba")
+	expectKeys(t, "Prefix(ff ff)", collectKeys(t, c.Prefix([]byte("\xff\xff"))), "\xff\xff", "\xff\xff\x01")
+
+	expectKeys(t, "Range", collectKeys(t, c.Range([]byte("b"), []byte("d"))), "b", "ba", "c")
+	expectKeys(t, "RangeClosed", collectKeys(t, c.RangeClosed([]byte("b"), []byte("d"))), "b", "ba", "c", "d")
+	expectKeys(t, "RangeClosed(bb, c)", collectKeys(t, c.RangeClosed([]byte("bb"), []byte("c"))), "c")
+	expectKeys(t, "ReverseRange", collectKeys(t, c.ReverseRange([]byte("b"), []byte("d"))), "c", "ba", "b")
+	expectKeys(t, "ReverseRange(bb, open)", collectKeys(t, c.ReverseRange([]byte("bb"), nil)),
+		"\xff\xff\x01", "\xff\xff", "\xff", "d", "c")
+	expectKeys(t, "Reverse", collectKeys(t, c.Reverse()),
+		"\xff\xff\x01", "\xff\xff", "\xff", "d", "c", "ba", "b", "a")
+}
+
+func TestCursorIteratorBreak(t *testing.T) {
+	c := openIterTestCursor(t, "a", "b", "c")
+
+	for _, seq := range []iter.Seq2[*Cursor, error]{c.All(), c.Range([]byte("b"), nil), c.Prefix([]byte("a"))} {
+		for _, err := range seq {
+			if err != nil {
+				t.Fatalf("Got error while iterating: %v", err)
+			}
+			break
+		}
+
+		// Leaving the loop resets the cursor.
+		var k []byte
+		if err := c.GetKey(&k); err == nil {
+			t.Errorf("Expected the cursor to be reset after break, positioned on %q", k)
+		}
+	}
+
+	expectKeys(t, "All after break", collectKeys(t, c.All()), "a", "b", "c")
+}
+
+func TestCursorPrefixString(t *testing.T) {
+	_, session := openTestSession(t, "")
+
+	if err := session.Create("table:prefix", "key_format=S,value_format=S"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	c, err := session.OpenCursor("table:prefix", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+	defer c.Close()
+
+	for _, k := range []string{"use", "user", "user:1", "user:10", "users", "uses"} {
+		c.SetKey(k)
+		c.SetValue("")
+		if err = c.Insert(); err != nil {
+			t.Fatalf("Got error while insert %q: %v", k, err)
+		}
+	}
+
+	collect := func(prefix []byte) []string {
+		var keys []string
+
+		for c, err := range c.Prefix(prefix) {
+			var k string
+
+			if err == nil {
+				err = c.GetKey(&k)
+			}
+			if err != nil {
+				t.Fatalf("Got error while iterating: %v", err)
+			}
+
+			keys = append(keys, k)
+		}
+
+		return keys
+	}
+
+	expectKeys(t, "Prefix(user:)", collect([]byte("user:")), "user:1", "user:10")
+	expectKeys(t, "Prefix(user)", collect([]byte("user")), "user", "user:1", "user:10", "users")
+
+	packed, err := Pack(nil, "S", nil, "user")
+	if err != nil {
+		t.Fatalf("Got error while pack: %v", err)
+	}
+
+	expectKeys(t, "Prefix(packed user)", collect(packed), "user")
+}
@@ -0,0 +1,145 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdint.h>
+#include <wiredtiger.h>
+
+// A log cursor search reads its key as typed values even on a raw cursor,
+// so raw mode is off while the key is set and searched for. The record
+// found is packed into the cursor either way, so the raw getters Next uses
+// read it as usual.
+static int wiredtiger_log_cursor_search(WT_CURSOR *cursor, uint32_t file, uint32_t offset, uint64_t counter) {
+	uint32_t raw = cursor->flags & WT_CURSTD_RAW;
+	int ret;
+
+	cursor->flags &= ~WT_CURSTD_RAW;
+	cursor->set_key(cursor, file, offset, counter);
+	ret = cursor->search(cursor);
+	cursor->flags |= raw;
+
+	return ret;
+}
+*/
+import "C"
+import (
+	"iter"
+)
+
+// LSN is a log sequence number: a position in the write-ahead log.
+type LSN struct {
+	File   uint32
+	Offset uint32
+}
+
+// Less reports whether l comes before o in the log.
+func (l LSN) Less(o LSN) bool {
+	return l.File < o.File || l.File == o.File && l.Offset < o.Offset
+}
+
+// LogRecord is one entry of a log cursor. Records of type
+// WT_LOGREC_COMMIT are split into one entry per operation, told apart by
+// Counter, with OpType one of the WT_LOGOP_* constants. FileID identifies
+// the file operations apply to; Key and Value are packed in its formats.
+type LogRecord struct {
+	LSN     LSN
+	Counter uint64
+	TxnID   uint64
+	RecType int
+	OpType  int
+	FileID  uint32
+	Key     []byte
+	Value   []byte
+}
+
+// LogCursor reads the write-ahead log of a connection opened with logging
+// enabled.
+type LogCursor struct {
+	c *Cursor
+	// Set once Seek has positioned on a record Next has yet to return.
+	pending bool
+}
+
+func (s *Session) OpenLogCursor() (*LogCursor, error) {
+	c, err := s.OpenCursor("log:", nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogCursor{c: c}, nil
+}
+
+func (lc *LogCursor) Close() error {
+	return lc.c.Close()
+}
+
+// Seek positions the cursor on the record at lsn, which the next call to
+// Next returns. It fails with WT_NOTFOUND if no record starts there.
+func (lc *LogCursor) Seek(lsn LSN) error {
+	lc.pending = false
+
+	if res := int(C.wiredtiger_log_cursor_search(lc.c.w, C.uint32_t(lsn.File), C.uint32_t(lsn.Offset), 0)); res != 0 {
+		return NewError(res, lc.c.session)
+	}
+
+	lc.pending = true
+	return nil
+}
+
+// Next returns the next record, or an error with code WT_NOTFOUND at the end
+// of the log.
+func (lc *LogCursor) Next() (*LogRecord, error) {
+	if lc.pending {
+		lc.pending = false
+	} else if err := lc.c.Next(); err != nil {
+		return nil, err
+	}
+
+	key, err := lc.c.rawKey()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := lc.c.rawValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeLogRecord(lc.c.session, key, value)
+}
+
+// decodeLogRecord unpacks a log cursor record: an "IIQ" key holding the LSN
+// and counter, and a "QIIIuu" value. Key and Value are copied out of value.
+func decodeLogRecord(s *Session, key, value []byte) (*LogRecord, error) {
+	r := new(LogRecord)
+
+	if err := UnPack(s, "IIQ", key, &r.LSN.File, &r.LSN.Offset, &r.Counter); err != nil {
+		return nil, err
+	}
+
+	if err := UnPack(s, "QIIIuu", value, &r.TxnID, &r.RecType, &r.OpType, &r.FileID, &r.Key, &r.Value); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Records iterates over the rest of the log in order; see Cursor.All for how
+// errors are reported.
+func (lc *LogCursor) Records() iter.Seq2[*LogRecord, error] {
+	return func(yield func(*LogRecord, error) bool) {
+		for {
+			r, err := lc.Next()
+			if err != nil {
+				if !IsNotFoundErr(err) {
+					yield(nil, err)
+				}
+				return
+			}
+
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package wiredtiger
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecodeLogRecord(t *testing.T) {
+	key := packTestRecord(t, "IIQ", uint32(3), uint32(4096), uint64(2))
+	value := packTestRecord(t, "QIIIuu", uint64(77), uint32(1), uint32(4), uint32(9), []byte("k1"), []byte("value"))
+
+	for _, tc := range []struct {
+		name       string
+		key, value []byte
+		record     *LogRecord
+	}{
+		{
+			name: "operation", key: key, value: value,
+			record: &LogRecord{LSN: LSN{3, 4096}, Counter: 2, TxnID: 77, RecType: 1, OpType: 4, FileID: 9, Key: []byte("k1"), Value: []byte("value")},
+		},
+		{
+			name: "no key or value", key: key,
+			value:  packTestRecord(t, "QIIIuu", uint64(0), uint32(2), uint32(0), uint32(0), []byte{}, []byte{}),
+			record: &LogRecord{LSN: LSN{3, 4096}, Counter: 2, RecType: 2},
+		},
+		{name: "empty key", key: nil, value: value},
+		{name: "key without counter", key: packTestRecord(t, "II", uint32(3), uint32(4096)), value: value},
+		{name: "value without items", key: key, value: packTestRecord(t, "QIII", uint64(77), uint32(1), uint32(4), uint32(9))},
+		{name: "value cut in the key item", key: key, value: value[:len(value)-len("value")-1]},
+		{name: "value cut in the header", key: key, value: value[:2]},
+	} {
+		r, err := decodeLogRecord(nil, tc.key, tc.value)
+
+		if tc.record == nil {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %+v", tc.name, r)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: got error %v", tc.name, err)
+			continue
+		}
+
+		if r.LSN != tc.record.LSN || r.Counter != tc.record.Counter || r.TxnID != tc.record.TxnID ||
+			r.RecType != tc.record.RecType || r.OpType != tc.record.OpType || r.FileID != tc.record.FileID ||
+			!bytes.Equal(r.Key, tc.record.Key) || !bytes.Equal(r.Value, tc.record.Value) {
+			t.Errorf("%s: got %+v, expected %+v", tc.name, r, tc.record)
+		}
+	}
+}
+
+func TestLSNLess(t *testing.T) {
+	for _, tc := range []struct {
+		a, b LSN
+		less bool
+	}{
+		{LSN{1, 100}, LSN{2, 0}, true},
+		{LSN{2, 0}, LSN{1, 100}, false},
+		{LSN{1, 100}, LSN{1, 200}, true},
+		{LSN{1, 100}, LSN{1, 100}, false},
+	} {
+		if less := tc.a.Less(tc.b); less != tc.less {
+			t.Errorf("%v.Less(%v) = %v", tc.a, tc.b, less)
+		}
+	}
+}
+
+func TestLogCursorSeek(t *testing.T) {
+	_, session := openTestSession(t, "log=(enabled=true)")
+
+	if err := session.Create("table:logged", "key_format=S,value_format=S"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	c, err := session.OpenCursor("table:logged", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+
+	for _, k := range []string{"k1", "k2", "k3", "k4"} {
+		c.SetKey(k)
+		c.SetValue("v" + k)
+		if err = c.Insert(); err != nil {
+			t.Fatalf("Got error while insert: %v", err)
+		}
+	}
+	c.Close()
+
+	lc, err := session.OpenLogCursor()
+	if err != nil {
+		t.Fatalf("Got error while open log cursor: %v", err)
+	}
+	defer lc.Close()
+
+	var records []*LogRecord
+
+	for r, err := range lc.Records() {
+		if err != nil {
+			t.Fatalf("Got error while read log: %v", err)
+		}
+		records = append(records, r)
+	}
+
+	// The first operation of a record in the second half of the log.
+	var want *LogRecord
+	for _, r := range records[len(records)/2:] {
+		if r.Counter == 0 {
+			want = r
+			break
+		}
+	}
+
+	if want == nil {
+		t.Fatalf("No record to seek to among %d", len(records))
+	}
+
+	if err = lc.Seek(want.LSN); err != nil {
+		t.Fatalf("Got error while seek to %v: %v", want.LSN, err)
+	}
+
+	got, err := lc.Next()
+	if err != nil {
+		t.Fatalf("Got error while next after seek: %v", err)
+	}
+
+	if got.LSN != want.LSN || got.Counter != want.Counter || got.TxnID != want.TxnID ||
+		got.RecType != want.RecType || got.OpType != want.OpType || got.FileID != want.FileID ||
+		!bytes.Equal(got.Key, want.Key) || !bytes.Equal(got.Value, want.Value) {
+		t.Errorf("Seek returned %+v, expected %+v", got, want)
+	}
+
+	if err = lc.Seek(LSN{want.LSN.File + 100, 0}); err == nil {
+		t.Error("Expected an error while seek past the end of the log")
+	}
+}
@@ -0,0 +1,245 @@
+package wiredtiger
+
+import (
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// MemFileSystem is a FileSystem holding every file in memory, for tests and
+// throwaway databases. Directories are implicit: a file exists in the
+// directory its name puts it in.
+type MemFileSystem struct {
+	mu    sync.Mutex
+	files map[string]*memFile
+}
+
+type memFile struct {
+	mu     sync.RWMutex
+	data   []byte
+	locked bool
+}
+
+// NewMemFileSystem returns an empty in-memory file system.
+func NewMemFileSystem() *MemFileSystem {
+	return &MemFileSystem{files: make(map[string]*memFile)}
+}
+
+func (fs *MemFileSystem) Open(name string, fileType FileType, flags FileOpenFlags) (File, error) {
+	name = path.Clean(name)
+
+	if fileType == FileTypeDirectory {
+		return memDir{}, nil
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	f, ok := fs.files[name]
+
+	switch {
+	case ok && flags&FileOpenExclusive != 0:
+		return nil, syscall.EEXIST
+	case !ok && flags&FileOpenCreate == 0:
+		return nil, syscall.ENOENT
+	case !ok:
+		f = new(memFile)
+		fs.files[name] = f
+	}
+
+	return &memHandle{f: f, readOnly: flags&FileOpenReadOnly != 0}, nil
+}
+
+func (fs *MemFileSystem) Exist(name string) (bool, error) {
+	fs.mu.Lock()
+	_, ok := fs.files[path.Clean(name)]
+	fs.mu.Unlock()
+
+	return ok, nil
+}
+
+func (fs *MemFileSystem) Remove(name string) error {
+	name = path.Clean(name)
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if _, ok := fs.files[name]; !ok {
+		return syscall.ENOENT
+	}
+
+	delete(fs.files, name)
+	return nil
+}
+
+func (fs *MemFileSystem) Rename(from, to string) error {
+	from, to = path.Clean(from), path.Clean(to)
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	f, ok := fs.files[from]
+	if !ok {
+		return syscall.ENOENT
+	}
+
+	delete(fs.files, from)
+	fs.files[to] = f
+	return nil
+}
+
+func (fs *MemFileSystem) Size(name string) (int64, error) {
+	fs.mu.Lock()
+	f, ok := fs.files[path.Clean(name)]
+	fs.mu.Unlock()
+
+	if !ok {
+		return 0, syscall.ENOENT
+	}
+
+	return f.size(), nil
+}
+
+func (fs *MemFileSystem) List(directory, prefix string) ([]string, error) {
+	var names []string
+
+	directory = path.Clean(directory)
+
+	fs.mu.Lock()
+	for name := range fs.files {
+		dir, base := path.Split(name)
+
+		if path.Clean(dir) == directory && strings.HasPrefix(base, prefix) {
+			names = append(names, base)
+		}
+	}
+	fs.mu.Unlock()
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func (f *memFile) size() int64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return int64(len(f.data))
+}
+
+// memHandle is an open MemFileSystem file; the data outlives it.
+type memHandle struct {
+	f        *memFile
+	readOnly bool
+}
+
+func (h *memHandle) ReadAt(p []byte, off int64) (int, error) {
+	h.f.mu.RLock()
+	defer h.f.mu.RUnlock()
+
+	if off < 0 {
+		return 0, syscall.EINVAL
+	}
+
+	if off >= int64(len(h.f.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, h.f.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+func (h *memHandle) WriteAt(p []byte, off int64) (int, error) {
+	if h.readOnly {
+		return 0, syscall.EBADF
+	}
+
+	if off < 0 {
+		return 0, syscall.EINVAL
+	}
+
+	h.f.mu.Lock()
+	defer h.f.mu.Unlock()
+
+	if end := off + int64(len(p)); end > int64(len(h.f.data)) {
+		h.f.grow(end)
+	}
+
+	return copy(h.f.data[off:], p), nil
+}
+
+func (h *memHandle) Size() (int64, error) {
+	return h.f.size(), nil
+}
+
+func (h *memHandle) Sync() error {
+	return nil
+}
+
+func (h *memHandle) Truncate(size int64) error {
+	if h.readOnly {
+		return syscall.EBADF
+	}
+
+	if size < 0 {
+		return syscall.EINVAL
+	}
+
+	h.f.mu.Lock()
+	defer h.f.mu.Unlock()
+
+	if size > int64(len(h.f.data)) {
+		h.f.grow(size)
+	} else {
+		h.f.data = h.f.data[:size]
+	}
+
+	return nil
+}
+
+func (h *memHandle) Lock(lock bool) error {
+	h.f.mu.Lock()
+	defer h.f.mu.Unlock()
+
+	if lock && h.f.locked {
+		return syscall.EBUSY
+	}
+
+	h.f.locked = lock
+	return nil
+}
+
+func (h *memHandle) Close() error {
+	return nil
+}
+
+// grow extends the file with zeroes to size bytes; the caller holds the lock.
+func (f *memFile) grow(size int64) {
+	if size <= int64(cap(f.data)) {
+		n := len(f.data)
+		f.data = f.data[:size]
+		clear(f.data[n:])
+		return
+	}
+
+	data := make([]byte, size, size+size/4)
+	copy(data, f.data)
+	f.data = data
+}
+
+// memDir stands in for a directory opened only to be synced.
+type memDir struct{}
+
+func (memDir) ReadAt(p []byte, off int64) (int, error)  { return 0, syscall.EISDIR }
+func (memDir) WriteAt(p []byte, off int64) (int, error) { return 0, syscall.EISDIR }
+func (memDir) Size() (int64, error)                     { return 0, nil }
+func (memDir) Sync() error                              { return nil }
+func (memDir) Truncate(size int64) error                { return syscall.EISDIR }
+func (memDir) Lock(lock bool) error                     { return nil }
+func (memDir) Close() error                             { return nil }
@@ -0,0 +1,127 @@
+package wiredtiger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"syscall"
+	"testing"
+)
+
+func TestMemFileSystem(t *testing.T) {
+	fs := NewMemFileSystem()
+
+	if _, err := fs.Open("db/WiredTiger", FileTypeRegular, 0); !errors.Is(err, syscall.ENOENT) {
+		t.Fatalf("Expected ENOENT opening a missing file, got %v", err)
+	}
+
+	f, err := fs.Open("db/WiredTiger", FileTypeRegular, FileOpenCreate)
+	if err != nil {
+		t.Fatalf("Got error while creating file: %v", err)
+	}
+
+	if _, err = fs.Open("db/WiredTiger", FileTypeRegular, FileOpenCreate|FileOpenExclusive); !errors.Is(err, syscall.EEXIST) {
+		t.Errorf("Expected EEXIST opening an existing file exclusively, got %v", err)
+	}
+
+	if _, err = f.WriteAt([]byte("world"), 6); err != nil {
+		t.Fatalf("Got error while writing: %v", err)
+	}
+
+	if _, err = f.WriteAt([]byte("hello"), 0); err != nil {
+		t.Fatalf("Got error while writing: %v", err)
+	}
+
+	buf := make([]byte, 11)
+	if n, err := f.ReadAt(buf, 0); n != 11 || err != nil {
+		t.Fatalf("Expected full read, got %d, %v", n, err)
+	}
+
+	if !bytes.Equal(buf, []byte("hello\x00world")) {
+		t.Errorf("Read unexpected data %q", buf)
+	}
+
+	if n, err := f.ReadAt(buf, 6); n != 5 || err != io.EOF {
+		t.Errorf("Expected short read with EOF, got %d, %v", n, err)
+	}
+
+	if err = f.Truncate(5); err != nil {
+		t.Fatalf("Got error while truncating: %v", err)
+	}
+
+	if size, _ := fs.Size("db/WiredTiger"); size != 5 {
+		t.Errorf("Expected size 5 after truncate, got %d", size)
+	}
+
+	if err = f.Lock(true); err != nil {
+		t.Errorf("Got error while locking: %v", err)
+	}
+
+	if err = f.Lock(true); !errors.Is(err, syscall.EBUSY) {
+		t.Errorf("Expected EBUSY locking twice, got %v", err)
+	}
+
+	for _, name := range []string{"db/WiredTiger.wt", "db/journal/WiredTigerLog.01", "other/WiredTiger.turtle"} {
+		if _, err = fs.Open(name, FileTypeData, FileOpenCreate); err != nil {
+			t.Fatalf("Got error while creating %s: %v", name, err)
+		}
+	}
+
+	if names, _ := fs.List("db/", "WiredTiger"); !reflect.DeepEqual(names, []string{"WiredTiger", "WiredTiger.wt"}) {
+		t.Errorf("Unexpected directory list %q", names)
+	}
+
+	if err = fs.Rename("db/WiredTiger.wt", "db/access.wt"); err != nil {
+		t.Fatalf("Got error while renaming: %v", err)
+	}
+
+	if ok, _ := fs.Exist("db/WiredTiger.wt"); ok {
+		t.Error("Renamed file still exists under its old name")
+	}
+
+	if err = fs.Remove("db/access.wt"); err != nil {
+		t.Errorf("Got error while removing: %v", err)
+	}
+
+	if err = fs.Remove("db/access.wt"); !errors.Is(err, syscall.ENOENT) {
+		t.Errorf("Expected ENOENT removing twice, got %v", err)
+	}
+}
+
+func TestFaultFileSystem(t *testing.T) {
+	fs := NewFaultFileSystem(NewMemFileSystem(), nil)
+
+	f, err := fs.Open("db/access.wt", FileTypeData, FileOpenCreate)
+	if err != nil {
+		t.Fatalf("Got error while creating file: %v", err)
+	}
+
+	fs.SetFault(func(op FaultOp, name string, offset int64, length int) error {
+		switch op {
+		case FaultWrite:
+			return &TornWrite{N: 3, Err: syscall.EIO}
+		case FaultSync:
+			return syscall.ENOSPC
+		}
+		return nil
+	})
+
+	if n, err := f.WriteAt([]byte("abcdef"), 0); n != 3 || !errors.Is(err, syscall.EIO) {
+		t.Errorf("Expected torn write of 3 bytes, got %d, %v", n, err)
+	}
+
+	if err = f.Sync(); !errors.Is(err, syscall.ENOSPC) {
+		t.Errorf("Expected ENOSPC from sync, got %v", err)
+	}
+
+	fs.SetFault(nil)
+
+	if size, _ := f.Size(); size != 3 {
+		t.Errorf("Expected the torn prefix to be written, size %d", size)
+	}
+
+	if err = f.Sync(); err != nil {
+		t.Errorf("Got error after clearing faults: %v", err)
+	}
+}
@@ -0,0 +1,506 @@
+package wiredtiger
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Typed alternatives to the configuration strings taken by Open,
+// OpenSession, Create and OpenCursor. Each renders the keys it sets and
+// leaves everything else at WiredTiger's defaults; values out of range are
+// rejected with EINVAL before WiredTiger is called.
+
+const (
+	minCacheSize = 1 << 20
+	maxCacheSize = 10 << 40
+	minLogFile   = 100 << 10
+	maxLogFile   = 2 << 30
+	maxWait      = 100000 * time.Second
+)
+
+type configBuilder []string
+
+func (b *configBuilder) add(key, value string) {
+	*b = append(*b, key+"="+value)
+}
+
+func (b *configBuilder) addInt(key string, v int64) {
+	b.add(key, strconv.FormatInt(v, 10))
+}
+
+func (b *configBuilder) addFlag(key string) {
+	*b = append(*b, key)
+}
+
+// addExtra appends a raw configuration string; later keys override earlier
+// ones, so it wins over the typed fields.
+func (b *configBuilder) addExtra(config string) {
+	if len(config) > 0 {
+		*b = append(*b, config)
+	}
+}
+
+func (b configBuilder) String() string {
+	return strings.Join(b, ",")
+}
+
+func inRange(v, lo, hi int64) bool {
+	return v >= lo && v <= hi
+}
+
+// waitSeconds converts a wait to the whole seconds WiredTiger takes,
+// rejecting anything it would have to round.
+func waitSeconds(d time.Duration) (int64, bool) {
+	if d < 0 || d > maxWait || d%time.Second != 0 {
+		return 0, false
+	}
+
+	return int64(d / time.Second), true
+}
+
+// LogOptions enables the write-ahead log.
+type LogOptions struct {
+	// Path is the log directory, relative to the home directory.
+	Path string
+	// FileMax is the size of a log file, from 100KB to 2GB.
+	FileMax int64
+	// Compressor names a compressor added with AddCompressor or loaded as
+	// an extension.
+	Compressor string
+	// KeepFiles keeps log files no longer needed for recovery instead of
+	// removing them.
+	KeepFiles bool
+}
+
+// logRemove reports whether the engine takes log=(remove), which replaced
+// log=(archive) in WiredTiger 10.
+var logRemove = sync.OnceValue(func() bool {
+	_, major, _, _ := Version()
+	return major >= 10
+})
+
+func (o *LogOptions) config() (string, error) {
+	config := configBuilder{"enabled=true"}
+
+	if len(o.Path) > 0 {
+		config.add("path", strconv.Quote(o.Path))
+	}
+
+	if o.FileMax != 0 {
+		if !inRange(o.FileMax, minLogFile, maxLogFile) {
+			return "", NewError(EINVAL, nil)
+		}
+		config.addInt("file_max", o.FileMax)
+	}
+
+	if len(o.Compressor) > 0 {
+		config.add("compressor", strconv.Quote(o.Compressor))
+	}
+
+	if o.KeepFiles {
+		if logRemove() {
+			config.add("remove", "false")
+		} else {
+			config.add("archive", "false")
+		}
+	}
+
+	return "(" + config.String() + ")", nil
+}
+
+// EvictionOptions tunes the eviction server. Zero fields keep the defaults.
+type EvictionOptions struct {
+	// ThreadsMin and ThreadsMax bound the eviction worker threads, from
+	// 1 to 20.
+	ThreadsMin int
+	ThreadsMax int
+	// Target and Trigger are the cache use, in percent, eviction works
+	// down to and at which application threads start evicting, from 10
+	// to 10000; Target must be below Trigger.
+	Target  int
+	Trigger int
+	// DirtyTarget and DirtyTrigger are the same for dirty data, from 1 to
+	// 10000.
+	DirtyTarget  int
+	DirtyTrigger int
+}
+
+func (o *EvictionOptions) config(config *configBuilder) error {
+	var threads configBuilder
+
+	for _, v := range []struct {
+		key    string
+		v      int
+		lo, hi int64
+		set    *configBuilder
+	}{
+		{"threads_min", o.ThreadsMin, 1, 20, &threads},
+		{"threads_max", o.ThreadsMax, 1, 20, &threads},
+		{"eviction_target", o.Target, 10, 10000, config},
+		{"eviction_trigger", o.Trigger, 10, 10000, config},
+		{"eviction_dirty_target", o.DirtyTarget, 1, 10000, config},
+		{"eviction_dirty_trigger", o.DirtyTrigger, 1, 10000, config},
+	} {
+		if v.v == 0 {
+			continue
+		}
+		if !inRange(int64(v.v), v.lo, v.hi) {
+			return NewError(EINVAL, nil)
+		}
+		v.set.addInt(v.key, int64(v.v))
+	}
+
+	if o.ThreadsMin != 0 && o.ThreadsMax != 0 && o.ThreadsMin > o.ThreadsMax {
+		return NewError(EINVAL, nil)
+	}
+
+	if o.Target != 0 && o.Trigger != 0 && o.Target >= o.Trigger {
+		return NewError(EINVAL, nil)
+	}
+
+	if o.DirtyTarget != 0 && o.DirtyTrigger != 0 && o.DirtyTarget >= o.DirtyTrigger {
+		return NewError(EINVAL, nil)
+	}
+
+	if len(threads) > 0 {
+		config.add("eviction", "("+threads.String()+")")
+	}
+
+	return nil
+}
+
+// CheckpointOptions schedules periodic checkpoints. Zero fields keep the
+// defaults.
+type CheckpointOptions struct {
+	// Wait is the interval between checkpoints, a whole number of seconds
+	// from 1 to 100000.
+	Wait time.Duration
+	// LogSize checkpoints after that many bytes of log, up to 2GB.
+	LogSize int64
+}
+
+// config returns an empty string when no field is set.
+func (o *CheckpointOptions) config() (string, error) {
+	var config configBuilder
+
+	if o.Wait != 0 {
+		wait, ok := waitSeconds(o.Wait)
+		if !ok || wait == 0 {
+			return "", NewError(EINVAL, nil)
+		}
+		config.addInt("wait", wait)
+	}
+
+	if o.LogSize != 0 {
+		if !inRange(o.LogSize, 0, maxLogFile) {
+			return "", NewError(EINVAL, nil)
+		}
+		config.addInt("log_size", o.LogSize)
+	}
+
+	if len(config) == 0 {
+		return "", nil
+	}
+
+	return "(" + config.String() + ")", nil
+}
+
+// OpenOptions configures OpenWith.
+type OpenOptions struct {
+	// Create creates the database if it does not exist.
+	Create bool
+	// CacheSize is the cache size in bytes, from 1MB to 10TB.
+	CacheSize int64
+	// SessionMax is the maximum number of open sessions.
+	SessionMax int
+
+	// Log, Eviction and Checkpoint are left at the defaults when nil.
+	Log        *LogOptions
+	Eviction   *EvictionOptions
+	Checkpoint *CheckpointOptions
+
+	// Statistics is the set of statistics maintained; StatisticsLog, when
+	// set, writes them to the home directory at that interval, a whole
+	// number of seconds.
+	Statistics    StatsMode
+	StatisticsLog time.Duration
+
+	// EventHandler and FileSystem are passed on as by OpenWithHandler and
+	// OpenWithFileSystem.
+	EventHandler EventHandler
+	FileSystem   FileSystem
+
+	// Extra is appended to the rendered configuration, for settings
+	// without a field.
+	Extra string
+}
+
+// Config renders the options as a wiredtiger_open configuration.
+func (o *OpenOptions) Config() (string, error) {
+	var config configBuilder
+
+	if o.Create {
+		config.addFlag("create")
+	}
+
+	if o.CacheSize != 0 {
+		if !inRange(o.CacheSize, minCacheSize, maxCacheSize) {
+			return "", NewError(EINVAL, nil)
+		}
+		config.addInt("cache_size", o.CacheSize)
+	}
+
+	if o.SessionMax != 0 {
+		if o.SessionMax < 1 {
+			return "", NewError(EINVAL, nil)
+		}
+		config.addInt("session_max", int64(o.SessionMax))
+	}
+
+	if o.Log != nil {
+		log, err := o.Log.config()
+		if err != nil {
+			return "", err
+		}
+		config.add("log", log)
+	}
+
+	if o.Eviction != nil {
+		if err := o.Eviction.config(&config); err != nil {
+			return "", err
+		}
+	}
+
+	if o.Checkpoint != nil {
+		checkpoint, err := o.Checkpoint.config()
+		if err != nil {
+			return "", err
+		} else if len(checkpoint) > 0 {
+			config.add("checkpoint", checkpoint)
+		}
+	}
+
+	switch o.Statistics {
+	case StatsDefault:
+	case StatsFast:
+		config.add("statistics", "(fast)")
+	case StatsAll:
+		config.add("statistics", "(all)")
+	default:
+		return "", NewError(EINVAL, nil)
+	}
+
+	if o.StatisticsLog != 0 {
+		wait, ok := waitSeconds(o.StatisticsLog)
+		if !ok || wait == 0 {
+			return "", NewError(EINVAL, nil)
+		}
+		config.add("statistics_log", "(wait="+strconv.FormatInt(wait, 10)+")")
+	}
+
+	config.addExtra(o.Extra)
+	return config.String(), nil
+}
+
+// OpenWith opens the database in home as configured by opts, which may be
+// nil.
+func OpenWith(home string, opts *OpenOptions) (*Connection, error) {
+	if opts == nil {
+		opts = &OpenOptions{}
+	}
+
+	config, err := opts.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.FileSystem != nil {
+		return openWithFileSystem(home, opts.EventHandler, opts.FileSystem, config)
+	}
+
+	return OpenWithHandler(home, opts.EventHandler, config)
+}
+
+// SessionOptions configures Connection.OpenSessionWith.
+type SessionOptions struct {
+	// Isolation is the default isolation of the session's transactions.
+	Isolation Isolation
+	// IgnoreCacheSize keeps the session's operations from being stalled
+	// by eviction when the cache is full.
+	IgnoreCacheSize bool
+
+	EventHandler EventHandler
+
+	// Extra is appended to the rendered configuration.
+	Extra string
+}
+
+// Config renders the options as a WT_CONNECTION::open_session
+// configuration.
+func (o *SessionOptions) Config() (string, error) {
+	var config configBuilder
+
+	switch o.Isolation {
+	case IsolationDefault:
+	case ReadUncommitted, ReadCommitted, Snapshot:
+		config.add("isolation", o.Isolation.String())
+	default:
+		return "", NewError(EINVAL, nil)
+	}
+
+	if o.IgnoreCacheSize {
+		config.add("ignore_cache_size", "true")
+	}
+
+	config.addExtra(o.Extra)
+	return config.String(), nil
+}
+
+// OpenSessionWith opens a session configured by opts, which may be nil.
+func (c *Connection) OpenSessionWith(opts *SessionOptions) (*Session, error) {
+	if opts == nil {
+		opts = &SessionOptions{}
+	}
+
+	config, err := opts.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.OpenSessionWithHandler(opts.EventHandler, config)
+}
+
+// CreateOptions configures Session.CreateWith.
+type CreateOptions struct {
+	// Formats gives the key and value formats and column names; the
+	// FormatsFor a struct may be used directly.
+	Formats
+	// BlockCompressor names the compressor for the object's blocks.
+	BlockCompressor string
+	// Exclusive fails the create if the object already exists.
+	Exclusive bool
+	// NoLog leaves the object's updates out of the log.
+	NoLog bool
+
+	// Extra is appended to the rendered configuration.
+	Extra string
+}
+
+// Config renders the options as a WT_SESSION::create configuration.
+func (o *CreateOptions) Config() (string, error) {
+	var config configBuilder
+
+	if len(o.KeyFormat) > 0 {
+		config.add("key_format", o.KeyFormat)
+	}
+
+	if len(o.ValueFormat) > 0 {
+		config.add("value_format", o.ValueFormat)
+	}
+
+	if len(o.Columns) > 0 {
+		for _, col := range o.Columns {
+			if len(col) == 0 || strings.ContainsAny(col, ",()=\"") {
+				return "", NewError(EINVAL, nil)
+			}
+		}
+		config.add("columns", "("+strings.Join(o.Columns, ",")+")")
+	}
+
+	if len(o.BlockCompressor) > 0 {
+		config.add("block_compressor", strconv.Quote(o.BlockCompressor))
+	}
+
+	if o.Exclusive {
+		config.add("exclusive", "true")
+	}
+
+	if o.NoLog {
+		config.add("log", "(enabled=false)")
+	}
+
+	config.addExtra(o.Extra)
+	return config.String(), nil
+}
+
+// CreateWith creates name as configured by opts, which may be nil.
+func (s *Session) CreateWith(name string, opts *CreateOptions) error {
+	if opts == nil {
+		opts = &CreateOptions{}
+	}
+
+	config, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	return s.Create(name, config)
+}
+
+// CursorOptions configures Session.OpenCursorWith.
+type CursorOptions struct {
+	// NoOverwrite makes Insert fail with WT_DUPLICATE_KEY on an existing
+	// key, and Update and Remove fail with WT_NOTFOUND on a missing one.
+	NoOverwrite bool
+	// Bulk opens a bulk-load cursor on a newly created object.
+	Bulk bool
+	// Append allocates record numbers for Insert on column stores.
+	Append bool
+	// Checkpoint opens a read-only cursor on the named checkpoint;
+	// "WiredTigerCheckpoint" is the most recent one.
+	Checkpoint string
+
+	// Extra is appended to the rendered configuration.
+	Extra string
+}
+
+// Config renders the options as a WT_SESSION::open_cursor configuration.
+func (o *CursorOptions) Config() (string, error) {
+	var config configBuilder
+
+	// A checkpoint cannot be written to, and a bulk cursor always
+	// appends to an empty object.
+	if len(o.Checkpoint) > 0 && (o.Bulk || o.Append || o.NoOverwrite) {
+		return "", NewError(EINVAL, nil)
+	}
+
+	if o.Bulk && o.NoOverwrite {
+		return "", NewError(EINVAL, nil)
+	}
+
+	if o.NoOverwrite {
+		config.add("overwrite", "false")
+	}
+
+	if o.Bulk {
+		config.add("bulk", "true")
+	}
+
+	if o.Append {
+		config.add("append", "true")
+	}
+
+	if len(o.Checkpoint) > 0 {
+		config.add("checkpoint", strconv.Quote(o.Checkpoint))
+	}
+
+	config.addExtra(o.Extra)
+	return config.String(), nil
+}
+
+// OpenCursorWith opens a cursor on uri, or a duplicate of toDup, as
+// configured by opts, which may be nil.
+func (s *Session) OpenCursorWith(uri string, toDup *Cursor, opts *CursorOptions) (*Cursor, error) {
+	if opts == nil {
+		opts = &CursorOptions{}
+	}
+
+	config, err := opts.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.OpenCursor(uri, toDup, config)
+}
@@ -0,0 +1,133 @@
+package wiredtiger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOpenOptionsConfig(t *testing.T) {
+	opts := &OpenOptions{
+		Create:     true,
+		CacheSize:  64 << 20,
+		Log:        &LogOptions{Path: "journal", FileMax: 1 << 20},
+		Eviction:   &EvictionOptions{ThreadsMin: 1, ThreadsMax: 4, Target: 80, Trigger: 95},
+		Checkpoint: &CheckpointOptions{Wait: time.Minute},
+		Statistics: StatsFast,
+		Extra:      "session_max=50",
+	}
+
+	config, err := opts.Config()
+	if err != nil {
+		t.Fatalf("Got error while rendering config: %v", err)
+	}
+
+	expected := `create,cache_size=67108864,log=(enabled=true,path="journal",file_max=1048576),` +
+		`eviction_target=80,eviction_trigger=95,eviction=(threads_min=1,threads_max=4),` +
+		`checkpoint=(wait=60),statistics=(fast),session_max=50`
+	if config != expected {
+		t.Errorf("Unexpected config %q, expected %q", config, expected)
+	}
+
+	if config, _ = (&OpenOptions{}).Config(); config != "" {
+		t.Errorf("Expected empty config for the zero value, got %q", config)
+	}
+
+	for _, bad := range []*OpenOptions{
+		{CacheSize: 1024},
+		{Log: &LogOptions{FileMax: 1}},
+		{Eviction: &EvictionOptions{ThreadsMax: 21}},
+		{Eviction: &EvictionOptions{ThreadsMin: 4, ThreadsMax: 2}},
+		{Eviction: &EvictionOptions{Target: 95, Trigger: 80}},
+		{Checkpoint: &CheckpointOptions{Wait: -time.Second}},
+		{Checkpoint: &CheckpointOptions{Wait: 500 * time.Millisecond}},
+		{Checkpoint: &CheckpointOptions{Wait: 1500 * time.Millisecond}},
+		{Checkpoint: &CheckpointOptions{LogSize: -1}},
+		{Statistics: StatsMode(9)},
+		{StatisticsLog: time.Millisecond},
+		{StatisticsLog: 500 * time.Millisecond},
+		{StatisticsLog: 2*time.Second + time.Nanosecond},
+	} {
+		if _, err = bad.Config(); err == nil {
+			t.Errorf("Expected an error for %+v", bad)
+		}
+	}
+}
+
+func TestCursorOptionsConfig(t *testing.T) {
+	config, err := (&CursorOptions{Checkpoint: "WiredTigerCheckpoint"}).Config()
+	if err != nil {
+		t.Fatalf("Got error while rendering config: %v", err)
+	}
+
+	if expected := `checkpoint="WiredTigerCheckpoint"`; config != expected {
+		t.Errorf("Unexpected config %q, expected %q", config, expected)
+	}
+
+	if config, _ = (&CursorOptions{NoOverwrite: true, Append: true}).Config(); config != "overwrite=false,append=true" {
+		t.Errorf("Unexpected config %q", config)
+	}
+
+	if _, err = (&CursorOptions{Bulk: true, Checkpoint: "c1"}).Config(); err == nil {
+		t.Error("Expected an error for a bulk cursor on a checkpoint")
+	}
+}
+
+func TestCreateOptionsConfig(t *testing.T) {
+	opts := &CreateOptions{Formats: Formats{KeyFormat: "S", ValueFormat: "Si", Columns: []string{"id", "name", "age"}}, NoLog: true}
+
+	config, err := opts.Config()
+	if err != nil {
+		t.Fatalf("Got error while rendering config: %v", err)
+	}
+
+	if expected := "key_format=S,value_format=Si,columns=(id,name,age),log=(enabled=false)"; config != expected {
+		t.Errorf("Unexpected config %q, expected %q", config, expected)
+	}
+
+	opts.Columns = []string{"a,b"}
+	if _, err = opts.Config(); err == nil {
+		t.Error("Expected an error for an invalid column name")
+	}
+}
+
+func TestCheckpointOptionsConfig(t *testing.T) {
+	for _, tc := range []struct {
+		opts     CheckpointOptions
+		expected string
+	}{
+		{CheckpointOptions{}, ""},
+		{CheckpointOptions{Wait: time.Second}, "checkpoint=(wait=1)"},
+		{CheckpointOptions{LogSize: 1 << 20}, "checkpoint=(log_size=1048576)"},
+		{CheckpointOptions{Wait: 2 * time.Minute, LogSize: 1 << 20}, "checkpoint=(wait=120,log_size=1048576)"},
+	} {
+		config, err := (&OpenOptions{Checkpoint: &tc.opts}).Config()
+		if err != nil {
+			t.Errorf("%+v: got error %v", tc.opts, err)
+		} else if config != tc.expected {
+			t.Errorf("%+v: got %q, expected %q", tc.opts, config, tc.expected)
+		}
+	}
+
+	if config, err := (&OpenOptions{StatisticsLog: 30 * time.Second}).Config(); err != nil || config != "statistics_log=(wait=30)" {
+		t.Errorf("Unexpected statistics log config %q, %v", config, err)
+	}
+}
+
+func TestLogOptionsKeepFiles(t *testing.T) {
+	defer func(f func() bool) { logRemove = f }(logRemove)
+
+	for _, tc := range []struct {
+		remove   bool
+		expected string
+	}{
+		{true, "log=(enabled=true,remove=false)"},
+		{false, "log=(enabled=true,archive=false)"},
+	} {
+		logRemove = func() bool { return tc.remove }
+
+		config, err := (&OpenOptions{Log: &LogOptions{KeepFiles: true}}).Config()
+		if err != nil || config != tc.expected {
+			t.Errorf("With remove %v: got %q, %v, expected %q", tc.remove, config, err, tc.expected)
+		}
+	}
+}
@@ -81,6 +81,7 @@ type wtpack struct {
 	havesize bool
 	size     int
 	vtype    byte
+	alias    bool
 }
 
 func (p *wtpack) start(pfmt *string) int {
@@ -376,11 +377,46 @@ func (p *wtpack) pack(buf []byte, i interface{}) ([]byte, int) {
 }
 
 func (p *wtpack) unpack(buf []byte, bcur *int, bend int, i interface{}) int {
+	// Only a trailing raw item or a zero-length field may be empty;
+	// anything else past the end of the buffer is truncated.
+	empty := p.vtype == 'x' || p.vtype == 'u' && !p.havesize || p.havesize && p.size == 0
+	if *bcur >= bend && !empty {
+		return EINVAL
+	}
+
 	switch p.vtype {
 	case 'x':
+		if *bcur+p.size > bend {
+			return EINVAL
+		}
 		*bcur += p.size
 	case 'S', 's':
 		var s int
+
+		if (p.vtype == 's' || p.havesize) && *bcur+p.size > bend {
+			return EINVAL
+		}
+
+		// Aliasing strings are read into byte slices, as Go strings must not
+		// change underneath their users.
+		if b, ok := i.(*[]byte); ok && p.alias {
+			if p.vtype == 's' || p.havesize == true {
+				s = p.size
+				*b = buf[*bcur : *bcur+s : *bcur+s]
+				*bcur += s
+			} else {
+				s = bytes.IndexByte(buf[*bcur:], 0)
+				if s == -1 {
+					return EINVAL
+				}
+
+				*b = buf[*bcur : *bcur+s : *bcur+s]
+				*bcur += s + 1
+			}
+
+			return 0
+		}
+
 		v, ok := i.(*string)
 		if ok == false {
 			return EINVAL
@@ -424,8 +460,16 @@ func (p *wtpack) unpack(buf []byte, bcur *int, bend int, i interface{}) int {
 			s = bend - *bcur
 		}
 
-		*v = (*v)[:0]
-		*v = append(*v, buf[*bcur:*bcur+s]...)
+		if s < 0 || *bcur+s > bend {
+			return EINVAL
+		}
+
+		if p.alias {
+			*v = buf[*bcur : *bcur+s : *bcur+s]
+		} else {
+			*v = (*v)[:0]
+			*v = append(*v, buf[*bcur:*bcur+s]...)
+		}
 		*bcur += s
 
 	case 'b':
@@ -546,6 +590,17 @@ func Pack(session *Session, pfmt string, buf []byte, a ...interface{}) ([]byte,
 }
 
 func UnPack(session *Session, pfmt string, buf []byte, a ...interface{}) error {
+	return unpack(session, pfmt, buf, false, a...)
+}
+
+// UnPackAlias is UnPack without copies: 'u' columns, and 'S' or 's' columns
+// read into a *[]byte, are set to slices of buf. They are only valid for as
+// long as buf is, which for cursor data ends with the next cursor operation.
+func UnPackAlias(session *Session, pfmt string, buf []byte, a ...interface{}) error {
+	return unpack(session, pfmt, buf, true, a...)
+}
+
+func unpack(session *Session, pfmt string, buf []byte, alias bool, a ...interface{}) error {
 	var res int
 	var cidx int
 	var bcur int
@@ -558,6 +613,7 @@ func UnPack(session *Session, pfmt string, buf []byte, a ...interface{}) error {
 	}
 
 	wtp := new(wtpack)
+	wtp.alias = alias
 	if res = wtp.start(&pfmt); res != 0 {
 		return NewError(EINVAL, session)
 	}
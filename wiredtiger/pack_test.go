@@ -20,7 +20,7 @@ func TestMain(m *testing.M) {
 func intTest(t *testing.T, v int64) {
 	var rv int64
 
-	b, e := Pack("q", v)
+	b, e := Pack(nil, "q", nil, v)
 	b2 := intPackTest(v)
 
 	switch {
@@ -31,7 +31,7 @@ func intTest(t *testing.T, v int64) {
 	case len(b) == 0:
 		t.Errorf("Expected []byte, got empty. len=%d cap=%d", len(b), cap(b))
 	default:
-		e = UnPack("q", b, &rv)
+		e = UnPack(nil, "q", b, &rv)
 
 		switch {
 		case e != nil:
@@ -47,7 +47,7 @@ func intTest(t *testing.T, v int64) {
 func uintTest(t *testing.T, v uint64) {
 	var rv uint64
 
-	b, e := Pack("Q", v)
+	b, e := Pack(nil, "Q", nil, v)
 	b2 := uintPackTest(v)
 
 	switch {
@@ -58,7 +58,7 @@ func uintTest(t *testing.T, v uint64) {
 	case len(b) == 0:
 		t.Errorf("Expected []byte, got empty. len=%d cap=%d", len(b), cap(b))
 	default:
-		e = UnPack("Q", b, &rv)
+		e = UnPack(nil, "Q", b, &rv)
 
 		switch {
 		case e != nil:
@@ -128,7 +128,7 @@ func TestIntPackNegMin(t *testing.T) {
 }
 
 func TestPack(t *testing.T) {
-	b, e := Pack("xbBq3sSuu", int8(-2), uint8(2), int64(-9223372036854775808), "ABCD", "Hello\x00World", []byte{1, 2, 3}, []byte{4, 5, 6, 7})
+	b, e := Pack(nil, "xbBq3sSuu", nil, int8(-2), uint8(2), int64(-9223372036854775808), "ABCD", "Hello\x00World", []byte{1, 2, 3}, []byte{4, 5, 6, 7})
 	b2 := generalPackTest()
 
 	switch {
@@ -147,7 +147,7 @@ func TestPack(t *testing.T) {
 		var v6 []byte
 		var v7 []byte
 
-		e = UnPack("xbBq3sSuu", b, &v1, &v2, &v3, &v4, &v5, &v6, &v7)
+		e = UnPack(nil, "xbBq3sSuu", b, &v1, &v2, &v3, &v4, &v5, &v6, &v7)
 
 		switch {
 		case e != nil:
@@ -200,3 +200,38 @@ func TestOpen(t *testing.T) {
 	cursor.Close()
 
 }
+
+func TestUnPackAlias(t *testing.T) {
+	b, e := Pack(nil, "Suu", nil, "key", []byte{1, 2, 3}, []byte{4, 5})
+
+	if e != nil {
+		t.Fatalf("Expected something, got error: %v", e)
+	}
+
+	var v1, v2, v3 []byte
+
+	if e = UnPackAlias(nil, "Suu", b, &v1, &v2, &v3); e != nil {
+		t.Fatalf("Expected something, got error: %v", e)
+	}
+
+	if string(v1) != "key" || !bytes.Equal(v2, []byte{1, 2, 3}) || !bytes.Equal(v3, []byte{4, 5}) {
+		t.Errorf("Returned unexpected values %q, % x, % x", v1, v2, v3)
+	}
+
+	// The values share the packed buffer.
+	b[len(b)-1] = 9
+	if v3[1] != 9 {
+		t.Errorf("Expected value to alias the buffer, got % x", v3)
+	}
+
+	// Appending must not overwrite the following column.
+	v2 = append(v2, 0xff)
+	if v3[0] != 4 {
+		t.Errorf("Append through an alias overwrote the next column: % x", v3)
+	}
+
+	var s string
+	if e = UnPack(nil, "Suu", b, &s, &v2, &v3); e != nil || s != "key" {
+		t.Errorf("Expected copying unpack to still work, got %q, %v", s, e)
+	}
+}
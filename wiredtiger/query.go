@@ -0,0 +1,224 @@
+package wiredtiger
+
+import (
+	"iter"
+	"strconv"
+)
+
+type CompareOp int
+
+const (
+	Eq CompareOp = iota
+	Ge
+	Gt
+	Le
+	Lt
+)
+
+func (op CompareOp) String() string {
+	switch op {
+	case Eq:
+		return "eq"
+	case Ge:
+		return "ge"
+	case Gt:
+		return "gt"
+	case Le:
+		return "le"
+	case Lt:
+		return "lt"
+	}
+
+	return "unknown"
+}
+
+type queryCond struct {
+	uri   string
+	op    CompareOp
+	value interface{}
+	bloom uint64
+}
+
+// Query selects the records of a table matching all of a set of conditions
+// on its indices, through a join cursor:
+//
+//	q := session.Query("table:people").
+//		Where("index:people:age", Ge, int32(30)).
+//		And("index:people:city", Eq, "Oslo")
+//
+//	for c, err := range q.Iter() {
+//		...
+//	}
+//
+// A condition value is an index key; a composite key is given as a
+// []interface{} holding its columns.
+type Query struct {
+	session *Session
+	uri     string
+	conds   []queryCond
+}
+
+// Query starts a query on the records of uri, which may carry a projection
+// such as "table:people(name,age)".
+func (s *Session) Query(uri string) *Query {
+	return &Query{session: s, uri: uri}
+}
+
+// Where adds a condition comparing the key of the index uri with value.
+func (q *Query) Where(uri string, op CompareOp, value interface{}) *Query {
+	q.conds = append(q.conds, queryCond{uri: uri, op: op, value: value})
+	return q
+}
+
+// And is Where, for readability when chaining.
+func (q *Query) And(uri string, op CompareOp, value interface{}) *Query {
+	return q.Where(uri, op, value)
+}
+
+// Bloom checks the last condition with a Bloom filter sized for count
+// entries instead of by walking the index, which pays off for conditions
+// matching many records.
+func (q *Query) Bloom(count uint64) *Query {
+	if len(q.conds) > 0 {
+		q.conds[len(q.conds)-1].bloom = count
+	}
+
+	return q
+}
+
+// Iter runs the query, yielding the join cursor positioned on each match in
+// turn; see Cursor.All for how errors are reported. All cursors the query
+// opens are closed when the loop finishes.
+func (q *Query) Iter() iter.Seq2[*Cursor, error] {
+	return func(yield func(*Cursor, error) bool) {
+		var refs []*Cursor
+
+		if len(q.conds) == 0 {
+			yield(nil, NewError(EINVAL, q.session))
+			return
+		}
+
+		join, err := q.session.OpenCursor("join:"+q.uri, nil, "")
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		// The index cursors must outlive the join cursor.
+		defer func() {
+			join.Close()
+			for _, c := range refs {
+				c.Close()
+			}
+		}()
+
+		for _, cond := range q.conds {
+			c, err := q.session.OpenCursor(cond.uri, nil, "")
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			refs = append(refs, c)
+
+			config, empty, err := cond.position(c)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			// Nothing can match all of the conditions.
+			if empty {
+				return
+			}
+
+			if err = q.session.Join(join, c, config); err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+
+		for {
+			if err = join.Next(); err != nil {
+				if !IsNotFoundErr(err) {
+					yield(nil, err)
+				}
+				return
+			}
+
+			if !yield(join, nil) {
+				return
+			}
+		}
+	}
+}
+
+// position places the index cursor on the bound of the condition and returns
+// the join configuration for it. The bound must be an existing key, so when
+// the value is missing the cursor moves to the nearest key on the matching
+// side and an inclusive comparison takes over; empty is set when there is no
+// such key.
+func (cond *queryCond) position(c *Cursor) (config string, empty bool, err error) {
+	if a, ok := cond.value.([]interface{}); ok {
+		err = c.SetKey(a...)
+	} else {
+		err = c.SetKey(cond.value)
+	}
+
+	if err != nil {
+		return "", false, err
+	}
+
+	exact, err := c.SearchNear()
+	if IsNotFoundErr(err) {
+		return "", true, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	op, step, empty := nearBound(cond.op, exact)
+
+	switch {
+	case empty:
+		return "", true, nil
+	case step > 0:
+		err = c.Next()
+	case step < 0:
+		err = c.Prev()
+	}
+
+	if IsNotFoundErr(err) {
+		return "", true, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	config = "compare=" + op.String()
+	if cond.bloom > 0 {
+		config += ",strategy=bloom,count=" + strconv.FormatUint(cond.bloom, 10)
+	}
+
+	return config, false, nil
+}
+
+// nearBound adjusts a condition to where SearchNear left the index cursor,
+// exact being its result: the cursor is to step forward or back by step
+// onto the nearest key on the matching side, which is then compared with
+// op. empty is set for an equality on a missing value.
+func nearBound(op CompareOp, exact int) (CompareOp, int, bool) {
+	switch {
+	case exact == 0:
+		return op, 0, false
+	case op == Eq:
+		return op, 0, true
+	case op == Ge || op == Gt:
+		if exact < 0 {
+			return Ge, 1, false
+		}
+		return Ge, 0, false
+	default:
+		if exact > 0 {
+			return Le, -1, false
+		}
+		return Le, 0, false
+	}
+}
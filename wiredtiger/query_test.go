@@ -0,0 +1,158 @@
+package wiredtiger
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestNearBound(t *testing.T) {
+	for _, tc := range []struct {
+		op    CompareOp
+		exact int
+		newOp CompareOp
+		step  int
+		empty bool
+	}{
+		{Eq, 0, Eq, 0, false},
+		{Gt, 0, Gt, 0, false},
+		{Lt, 0, Lt, 0, false},
+		{Eq, -1, Eq, 0, true},
+		{Eq, 1, Eq, 0, true},
+		{Ge, -1, Ge, 1, false},
+		{Gt, -1, Ge, 1, false},
+		{Gt, 1, Ge, 0, false},
+		{Le, 1, Le, -1, false},
+		{Lt, 1, Le, -1, false},
+		{Lt, -1, Le, 0, false},
+	} {
+		op, step, empty := nearBound(tc.op, tc.exact)
+		if op != tc.newOp || step != tc.step || empty != tc.empty {
+			t.Errorf("nearBound(%v, %d) = %v, %d, %v, expected %v, %d, %v",
+				tc.op, tc.exact, op, step, empty, tc.newOp, tc.step, tc.empty)
+		}
+	}
+}
+
+func openQueryTestSession(t *testing.T) *Session {
+	_, session := openTestSession(t, "")
+
+	for _, create := range [][2]string{
+		{"table:people", "key_format=S,value_format=Si,columns=(name,city,age)"},
+		{"index:people:city", "columns=(city)"},
+		{"index:people:age", "columns=(age)"},
+	} {
+		if err := session.Create(create[0], create[1]); err != nil {
+			t.Fatalf("Got error while create %s: %v", create[0], err)
+		}
+	}
+
+	c, err := session.OpenCursor("table:people", nil, "")
+	if err != nil {
+		t.Fatalf("Got error while open cursor: %v", err)
+	}
+	defer c.Close()
+
+	for _, p := range []struct {
+		name, city string
+		age        int32
+	}{
+		{"alice", "Oslo", 30},
+		{"bob", "Oslo", 25},
+		{"carol", "Bergen", 35},
+		{"dave", "Oslo", 40},
+		{"erin", "Bergen", 28},
+	} {
+		c.SetKey(p.name)
+		c.SetValue(p.city, p.age)
+		if err = c.Insert(); err != nil {
+			t.Fatalf("Got error while insert: %v", err)
+		}
+	}
+
+	return session
+}
+
+func queryNames(t *testing.T, q *Query) string {
+	var names []string
+
+	for c, err := range q.Iter() {
+		var name string
+
+		if err == nil {
+			err = c.GetKey(&name)
+		}
+		if err != nil {
+			t.Fatalf("Got error while running query: %v", err)
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func TestQuery(t *testing.T) {
+	session := openQueryTestSession(t)
+
+	for _, tc := range []struct {
+		name     string
+		query    *Query
+		expected string
+	}{
+		{"single", session.Query("table:people").Where("index:people:age", Ge, int32(30)), "alice,carol,dave"},
+		{"single missing value", session.Query("table:people").Where("index:people:age", Gt, int32(31)), "carol,dave"},
+		{"upper bound", session.Query("table:people").Where("index:people:age", Lt, int32(29)), "bob,erin"},
+		{"multiple", session.Query("table:people").
+			Where("index:people:age", Ge, int32(30)).
+			And("index:people:city", Eq, "Oslo"), "alice,dave"},
+		{"range", session.Query("table:people").
+			Where("index:people:age", Gt, int32(25)).
+			And("index:people:age", Le, int32(35)), "alice,carol,erin"},
+		{"bloom", session.Query("table:people").
+			Where("index:people:age", Ge, int32(30)).
+			And("index:people:city", Eq, "Oslo").Bloom(100), "alice,dave"},
+		{"empty missing value", session.Query("table:people").Where("index:people:city", Eq, "Paris"), ""},
+		{"empty past the end", session.Query("table:people").Where("index:people:age", Gt, int32(40)), ""},
+		{"empty intersection", session.Query("table:people").
+			Where("index:people:city", Eq, "Bergen").
+			And("index:people:age", Ge, int32(36)), ""},
+	} {
+		if names := queryNames(t, tc.query); names != tc.expected {
+			t.Errorf("%s: got %q, expected %q", tc.name, names, tc.expected)
+		}
+	}
+}
+
+func TestQueryReuse(t *testing.T) {
+	session := openQueryTestSession(t)
+
+	q := session.Query("table:people").
+		Where("index:people:city", Eq, "Oslo").
+		And("index:people:age", Lt, int32(40))
+
+	for i := 0; i < 3; i++ {
+		if names := queryNames(t, q); names != "alice,bob" {
+			t.Errorf("Run %d: got %q, expected %q", i+1, names, "alice,bob")
+		}
+	}
+
+	// Stopping early releases the cursors, so the next run starts afresh.
+	for _, err := range q.Iter() {
+		if err != nil {
+			t.Fatalf("Got error while running query: %v", err)
+		}
+		break
+	}
+
+	if names := queryNames(t, q); names != "alice,bob" {
+		t.Errorf("After break: got %q, expected %q", names, "alice,bob")
+	}
+
+	for _, err := range session.Query("table:people").Iter() {
+		if err == nil {
+			t.Error("Expected an error for a query without conditions")
+		}
+	}
+}
@@ -117,8 +117,9 @@ import "C"
 import "unsafe"
 
 type Session struct {
-	w    *C.WT_SESSION
-	conn *Connection
+	w       *C.WT_SESSION
+	conn    *Connection
+	handler *eventHandler
 }
 
 // General
@@ -134,7 +135,10 @@ func (s *Session) Close(config string) error {
 	result := int(C.wiredtiger_session_close(s.w, configC))
 
 	if result == 0 {
+		unregisterSession(s.w)
 		s.w = nil
+		s.handler.free()
+		s.handler = nil
 		return nil
 	}
 
@@ -0,0 +1,130 @@
+package wiredtiger
+
+import (
+	"strings"
+)
+
+type StatsMode int
+
+const (
+	// StatsDefault gathers the statistics the connection's "statistics"
+	// setting enables.
+	StatsDefault StatsMode = iota
+	// StatsFast gathers the statistics that are cheap to collect.
+	StatsFast
+	// StatsAll gathers every statistic, including those that walk the tree.
+	StatsAll
+)
+
+// StatsOptions configures Session.Statistics. The zero value gathers the
+// connection's configured statistics without resetting them.
+type StatsOptions struct {
+	Mode StatsMode
+	// Clear resets the statistics after they are read.
+	Clear bool
+	// Join is the join cursor whose statistics are read when the URI is
+	// "join".
+	Join *Cursor
+}
+
+// Stat is one statistic: WiredTiger's description, the value formatted for
+// display and the raw value.
+type Stat struct {
+	Description string
+	Printable   string
+	Value       int64
+}
+
+// Statistics reads the statistics of uri, keyed by the WT_STAT_CONN_*,
+// WT_STAT_DSRC_* or WT_STAT_JOIN_* identifiers. An empty uri reads the
+// connection statistics, "join" those of opts.Join, and any other uri, such
+// as "table:access", the statistics of that data source. opts may be nil.
+func (s *Session) Statistics(uri string, opts *StatsOptions) (map[int]Stat, error) {
+	var dup *Cursor
+	var modes []string
+
+	if opts == nil {
+		opts = &StatsOptions{}
+	}
+
+	if uri == "join" {
+		if opts.Join == nil {
+			return nil, NewError(EINVAL, s)
+		}
+		dup = opts.Join
+	}
+
+	if !strings.HasPrefix(uri, "statistics:") {
+		uri = "statistics:" + uri
+	}
+
+	switch opts.Mode {
+	case StatsDefault:
+	case StatsFast:
+		modes = append(modes, "fast")
+	case StatsAll:
+		modes = append(modes, "all")
+	default:
+		return nil, NewError(EINVAL, s)
+	}
+
+	if opts.Clear {
+		modes = append(modes, "clear")
+	}
+
+	config := ""
+	if len(modes) > 0 {
+		config = "statistics=(" + strings.Join(modes, ",") + ")"
+	}
+
+	c, err := s.OpenCursor(uri, dup, config)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	stats := make(map[int]Stat)
+
+	for _, err := range c.All() {
+		var key, value []byte
+
+		if err != nil {
+			return nil, err
+		}
+
+		if key, err = c.rawKey(); err != nil {
+			return nil, err
+		}
+
+		if value, err = c.rawValue(); err != nil {
+			return nil, err
+		}
+
+		id, st, err := decodeStat(s, key, value)
+		if err != nil {
+			return nil, err
+		}
+
+		stats[id] = st
+	}
+
+	return stats, nil
+}
+
+// decodeStat unpacks a statistics cursor record: an "i" key holding the
+// statistic's identifier and an "SSq" value. Identifiers this package has
+// no constant for are kept like any other.
+func decodeStat(s *Session, key, value []byte) (int, Stat, error) {
+	var id int32
+	var st Stat
+
+	if err := UnPack(s, "i", key, &id); err != nil {
+		return 0, Stat{}, err
+	}
+
+	if err := UnPack(s, "SSq", value, &st.Description, &st.Printable, &st.Value); err != nil {
+		return 0, Stat{}, err
+	}
+
+	return int(id), st, nil
+}
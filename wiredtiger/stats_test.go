@@ -0,0 +1,60 @@
+package wiredtiger
+
+import (
+	"testing"
+)
+
+func packTestRecord(t *testing.T, format string, a ...interface{}) []byte {
+	t.Helper()
+
+	b, err := Pack(nil, format, nil, a...)
+	if err != nil {
+		t.Fatalf("Got error while packing %q: %v", format, err)
+	}
+
+	return b
+}
+
+func TestDecodeStat(t *testing.T) {
+	value := packTestRecord(t, "SSq", "cache: bytes currently in the cache", "1048576", int64(1048576))
+
+	for _, tc := range []struct {
+		name       string
+		key, value []byte
+		id         int
+		stat       Stat
+		ok         bool
+	}{
+		{
+			name: "known", key: packTestRecord(t, "i", int32(WT_STAT_CONN_CACHE_BYTES_INUSE)), value: value,
+			id: WT_STAT_CONN_CACHE_BYTES_INUSE, stat: Stat{"cache: bytes currently in the cache", "1048576", 1048576}, ok: true,
+		},
+		{
+			name: "unknown key", key: packTestRecord(t, "i", int32(987654)), value: packTestRecord(t, "SSq", "new: stat", "-3", int64(-3)),
+			id: 987654, stat: Stat{"new: stat", "-3", -3}, ok: true,
+		},
+		{
+			name: "empty strings", key: packTestRecord(t, "i", int32(1)), value: packTestRecord(t, "SSq", "", "", int64(0)),
+			id: 1, stat: Stat{}, ok: true,
+		},
+		{name: "empty key", key: nil, value: value},
+		{name: "empty value", key: packTestRecord(t, "i", int32(1)), value: nil},
+		{name: "value without number", key: packTestRecord(t, "i", int32(1)), value: packTestRecord(t, "SS", "a", "b")},
+		{name: "value cut in a string", key: packTestRecord(t, "i", int32(1)), value: value[:5]},
+	} {
+		id, st, err := decodeStat(nil, tc.key, tc.value)
+
+		if !tc.ok {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %d, %+v", tc.name, id, st)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: got error %v", tc.name, err)
+		} else if id != tc.id || st != tc.stat {
+			t.Errorf("%s: got %d, %+v, expected %d, %+v", tc.name, id, st, tc.id, tc.stat)
+		}
+	}
+}
@@ -0,0 +1,397 @@
+package wiredtiger
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Struct mapping
+//
+// Exported struct fields map to columns in declaration order. The field tag
+//
+//	`wt:"name,format,key"`
+//
+// sets the column name (default: the field name), the pack format of the
+// column (default: derived from the field type) and marks the column as
+// part of the key; untagged columns belong to the value. A tag of "-" skips
+// the field.
+//
+//	Go type                      default  allowed formats
+//	int8                         b        b
+//	int16, int32, int64, int     q        h i l q
+//	uint8                        B        B t
+//	uint16, uint32, uint64, uint Q        H I L Q r
+//	string                       S        S, Ns
+//	[]byte                       u        u, Nu
+//
+// Named types with these underlying types are accepted as well.
+
+type structField struct {
+	index  []int
+	name   string
+	format string
+	vtype  byte
+}
+
+type structInfo struct {
+	keys        []structField
+	values      []structField
+	all         []structField
+	keyFormat   string
+	valueFormat string
+	allFormat   string
+}
+
+var structInfos sync.Map
+
+// Which columns of a struct to pack: the key, the value, or, for types that
+// stand for a whole key or value by themselves, all of them.
+type structPart int
+
+const (
+	structKey structPart = iota
+	structValue
+	structAll
+)
+
+func structInfoOf(t reflect.Type) (*structInfo, error) {
+	if info, ok := structInfos.Load(t); ok {
+		return info.(*structInfo), nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("wiredtiger: %s is not a struct", t)
+	}
+
+	info := new(structInfo)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		tag := sf.Tag.Get("wt")
+		if tag == "-" {
+			continue
+		}
+
+		f := structField{index: sf.Index, name: sf.Name}
+		key := false
+
+		parts := strings.Split(tag, ",")
+		if len(parts[0]) > 0 {
+			f.name = parts[0]
+		}
+
+		if len(parts) > 1 {
+			f.format = parts[1]
+		}
+
+		for _, opt := range parts[min(2, len(parts)):] {
+			if opt != "key" {
+				return nil, fmt.Errorf("wiredtiger: %s.%s: unknown tag option %q", t, sf.Name, opt)
+			}
+			key = true
+		}
+
+		if err := f.check(sf.Type); err != nil {
+			return nil, fmt.Errorf("wiredtiger: %s.%s: %v", t, sf.Name, err)
+		}
+
+		if key {
+			info.keys = append(info.keys, f)
+			info.keyFormat += f.format
+		} else {
+			info.values = append(info.values, f)
+			info.valueFormat += f.format
+		}
+
+		info.all = append(info.all, f)
+		info.allFormat += f.format
+	}
+
+	info2, _ := structInfos.LoadOrStore(t, info)
+	return info2.(*structInfo), nil
+}
+
+// check fills in the default format for the field type and verifies an
+// explicit one suits it.
+func (f *structField) check(t reflect.Type) error {
+	var def, allowed string
+
+	switch t.Kind() {
+	case reflect.Int8:
+		def, allowed = "b", "b"
+	case reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		def, allowed = "q", "hilq"
+	case reflect.Uint8:
+		def, allowed = "B", "Bt"
+	case reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		def, allowed = "Q", "HILQr"
+	case reflect.String:
+		def, allowed = "S", "Ss"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			def, allowed = "u", "u"
+			break
+		}
+		fallthrough
+	default:
+		return fmt.Errorf("unsupported type %s", t)
+	}
+
+	if len(f.format) == 0 {
+		f.format = def
+	}
+
+	size := strings.TrimLeft(f.format, "0123456789")
+	if len(size) != 1 || strings.IndexByte(allowed, size[0]) < 0 {
+		return fmt.Errorf("format %q does not suit type %s", f.format, t)
+	}
+
+	// A count is a size for strings, raw items and bit fields, but a repeat
+	// for numbers, which would need more than one field.
+	f.vtype = size[0]
+	if len(size) != len(f.format) && strings.IndexByte("stu", f.vtype) < 0 {
+		return fmt.Errorf("format %q repeats a column", f.format)
+	}
+
+	return nil
+}
+
+func (f *structField) value(v reflect.Value) (interface{}, error) {
+	fv := v.FieldByIndex(f.index)
+
+	switch f.vtype {
+	case 'b':
+		return int8(fv.Int()), nil
+	case 'h', 'i', 'l', 'q':
+		return fv.Int(), nil
+	case 'B', 't':
+		return uint8(fv.Uint()), nil
+	case 'H', 'I', 'L', 'Q', 'r':
+		return fv.Uint(), nil
+	case 's', 'S':
+		return fv.String(), nil
+	case 'u':
+		return fv.Bytes(), nil
+	}
+
+	return nil, fmt.Errorf("wiredtiger: column %s: unsupported format %q", f.name, f.format)
+}
+
+func (f *structField) target() interface{} {
+	switch f.vtype {
+	case 'b':
+		return new(int8)
+	case 'h', 'i', 'l', 'q':
+		return new(int64)
+	case 'B', 't':
+		return new(uint8)
+	case 'H', 'I', 'L', 'Q', 'r':
+		return new(uint64)
+	case 's', 'S':
+		return new(string)
+	}
+
+	return new([]byte)
+}
+
+func (f *structField) set(v reflect.Value, target interface{}) error {
+	fv := v.FieldByIndex(f.index)
+
+	switch t := target.(type) {
+	case *int8:
+		fv.SetInt(int64(*t))
+	case *int64:
+		if fv.OverflowInt(*t) {
+			return fmt.Errorf("wiredtiger: column %s: %d overflows %s", f.name, *t, fv.Type())
+		}
+		fv.SetInt(*t)
+	case *uint8:
+		fv.SetUint(uint64(*t))
+	case *uint64:
+		if fv.OverflowUint(*t) {
+			return fmt.Errorf("wiredtiger: column %s: %d overflows %s", f.name, *t, fv.Type())
+		}
+		fv.SetUint(*t)
+	case *string:
+		fv.SetString(*t)
+	case *[]byte:
+		fv.SetBytes(*t)
+	}
+
+	return nil
+}
+
+func structOf(v interface{}, settable bool) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	} else if settable {
+		return reflect.Value{}, fmt.Errorf("wiredtiger: %T is not a pointer to a struct", v)
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("wiredtiger: %T is not a struct", v)
+	}
+
+	return rv, nil
+}
+
+// packStruct packs the selected columns of v, which must match format.
+func packStruct(session *Session, format string, buf []byte, v interface{}, part structPart) ([]byte, error) {
+	rv, err := structOf(v, false)
+	if err != nil {
+		return buf, err
+	}
+
+	info, err := structInfoOf(rv.Type())
+	if err != nil {
+		return buf, err
+	}
+
+	fields, sformat := info.columns(part)
+	if sformat != format {
+		return buf, fmt.Errorf("wiredtiger: %s has format %q, expected %q", rv.Type(), sformat, format)
+	}
+
+	a := make([]interface{}, len(fields))
+	for i := range fields {
+		if a[i], err = fields[i].value(rv); err != nil {
+			return buf, err
+		}
+	}
+
+	return Pack(session, format, buf, a...)
+}
+
+// unpackStruct fills the selected columns of the struct v points to.
+func unpackStruct(session *Session, format string, buf []byte, v interface{}, part structPart) error {
+	rv, err := structOf(v, true)
+	if err != nil {
+		return err
+	}
+
+	info, err := structInfoOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	fields, sformat := info.columns(part)
+	if sformat != format {
+		return fmt.Errorf("wiredtiger: %s has format %q, expected %q", rv.Type(), sformat, format)
+	}
+
+	a := make([]interface{}, len(fields))
+	for i := range fields {
+		a[i] = fields[i].target()
+	}
+
+	if err = UnPack(session, format, buf, a...); err != nil {
+		return err
+	}
+
+	for i := range fields {
+		if err = fields[i].set(rv, a[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (info *structInfo) columns(part structPart) ([]structField, string) {
+	switch part {
+	case structKey:
+		return info.keys, info.keyFormat
+	case structValue:
+		return info.values, info.valueFormat
+	}
+
+	return info.all, info.allFormat
+}
+
+// Formats describes the table layout of a mapped struct.
+type Formats struct {
+	KeyFormat   string
+	ValueFormat string
+	Columns     []string
+}
+
+// FormatsFor derives the key and value formats and column names of the
+// struct v, or the struct v points to.
+func FormatsFor(v interface{}) (Formats, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil {
+		return Formats{}, fmt.Errorf("wiredtiger: %T is not a struct", v)
+	}
+
+	info, err := structInfoOf(t)
+	if err != nil {
+		return Formats{}, err
+	}
+
+	if len(info.keys) == 0 || len(info.values) == 0 {
+		return Formats{}, fmt.Errorf("wiredtiger: %s needs both key and value columns", t)
+	}
+
+	f := Formats{KeyFormat: info.keyFormat, ValueFormat: info.valueFormat}
+
+	for _, c := range info.keys {
+		f.Columns = append(f.Columns, c.name)
+	}
+
+	for _, c := range info.values {
+		f.Columns = append(f.Columns, c.name)
+	}
+
+	return f, nil
+}
+
+// Config renders the formats as Session.Create configuration.
+func (f Formats) Config() string {
+	return "key_format=" + f.KeyFormat + ",value_format=" + f.ValueFormat + ",columns=(" + strings.Join(f.Columns, ",") + ")"
+}
+
+func (c *Cursor) SetKeyStruct(v interface{}) error {
+	var res error
+	c.keyPack, res = packStruct(c.session, c.keyFormat, c.keyPack, v, structKey)
+
+	c.keySetExt = res == nil
+	return res
+}
+
+func (c *Cursor) SetValueStruct(v interface{}) error {
+	var res error
+	c.valuePack, res = packStruct(c.session, c.valueFormat, c.valuePack, v, structValue)
+
+	c.valueSetExt = res == nil
+	return res
+}
+
+func (c *Cursor) GetKeyStruct(v interface{}) error {
+	d, err := c.rawKey()
+	if err != nil {
+		return err
+	}
+
+	return unpackStruct(c.session, c.keyFormat, d, v, structKey)
+}
+
+func (c *Cursor) GetValueStruct(v interface{}) error {
+	d, err := c.rawValue()
+	if err != nil {
+		return err
+	}
+
+	return unpackStruct(c.session, c.valueFormat, d, v, structValue)
+}
@@ -0,0 +1,92 @@
+package wiredtiger
+
+import (
+	"bytes"
+	"testing"
+)
+
+type userID uint32
+
+type structTestRecord struct {
+	ID      userID `wt:"id,I,key"`
+	Region  string `wt:"region,,key"`
+	Name    string `wt:"name"`
+	Code    string `wt:"code,4s"`
+	Age     int8
+	Balance int64  `wt:"balance,q"`
+	Avatar  []byte `wt:"avatar"`
+	Cached  string `wt:"-"`
+	private int
+}
+
+func TestFormatsFor(t *testing.T) {
+	f, err := FormatsFor(&structTestRecord{})
+	if err != nil {
+		t.Fatalf("Got error while deriving formats: %v", err)
+	}
+
+	expected := "key_format=IS,value_format=S4sbqu,columns=(id,region,name,code,Age,balance,avatar)"
+	if f.Config() != expected {
+		t.Errorf("Unexpected config %q, expected %q", f.Config(), expected)
+	}
+
+	type badFormat struct {
+		Key int64 `wt:"k,Q,key"`
+		Val string
+	}
+
+	if _, err = FormatsFor(badFormat{}); err == nil {
+		t.Error("Expected an error for an unsigned format on a signed field")
+	}
+
+	type noKey struct {
+		Val string
+	}
+
+	if _, err = FormatsFor(noKey{}); err == nil {
+		t.Error("Expected an error for a struct without key columns")
+	}
+}
+
+func TestStructPack(t *testing.T) {
+	in := structTestRecord{ID: 7, Region: "eu", Name: "Ann", Code: "ABCD", Age: -3, Balance: -1 << 40, Avatar: []byte{1, 2, 3}, Cached: "x"}
+
+	key, err := packStruct(nil, "IS", nil, in, structKey)
+	if err != nil {
+		t.Fatalf("Got error while packing key: %v", err)
+	}
+
+	expected, _ := Pack(nil, "IS", nil, uint32(7), "eu")
+	if !bytes.Equal(key, expected) {
+		t.Errorf("Unexpected key % x, expected % x", key, expected)
+	}
+
+	value, err := packStruct(nil, "S4sbqu", nil, &in, structValue)
+	if err != nil {
+		t.Fatalf("Got error while packing value: %v", err)
+	}
+
+	var out structTestRecord
+
+	if err = unpackStruct(nil, "IS", key, &out, structKey); err != nil {
+		t.Fatalf("Got error while unpacking key: %v", err)
+	}
+
+	if err = unpackStruct(nil, "S4sbqu", value, &out, structValue); err != nil {
+		t.Fatalf("Got error while unpacking value: %v", err)
+	}
+
+	out.Cached = in.Cached
+	if out.ID != in.ID || out.Region != in.Region || out.Name != in.Name || out.Code != in.Code ||
+		out.Age != in.Age || out.Balance != in.Balance || !bytes.Equal(out.Avatar, in.Avatar) {
+		t.Errorf("Round trip mismatch: %+v != %+v", out, in)
+	}
+
+	if _, err = packStruct(nil, "QS", nil, in, structKey); err == nil {
+		t.Error("Expected an error packing into a mismatched format")
+	}
+
+	if err = unpackStruct(nil, "IS", key, out, structKey); err == nil {
+		t.Error("Expected an error unpacking into a non-pointer")
+	}
+}
@@ -0,0 +1,256 @@
+package wiredtiger
+
+import (
+	"context"
+	"reflect"
+)
+
+// Table is a typed handle on a WiredTiger table, with keys of type K and
+// values of type V. Scalar types are packed with the table's formats as
+// Cursor.SetKey would; struct types map all of their columns through wt
+// tags, as described for Cursor.SetKeyStruct, ignoring the key option.
+//
+// Like the session it is opened in, a Table must not be used by more than
+// one goroutine at a time.
+type Table[K, V any] struct {
+	session *Session
+	uri     string
+	config  string
+	cursor  *Cursor
+	insert  *Cursor
+}
+
+// OpenTable opens a typed handle on uri; config is passed to the
+// underlying cursor.
+func OpenTable[K, V any](session *Session, uri, config string) (*Table[K, V], error) {
+	c, err := session.OpenCursor(uri, nil, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table[K, V]{session: session, uri: uri, config: config, cursor: c}, nil
+}
+
+func (t *Table[K, V]) Close() error {
+	var err error
+
+	if t.insert != nil {
+		err = t.insert.Close()
+		t.insert = nil
+	}
+
+	if t.cursor != nil {
+		if cerr := t.cursor.Close(); err == nil {
+			err = cerr
+		}
+		t.cursor = nil
+	}
+
+	return err
+}
+
+func (t *Table[K, V]) GetSession() *Session {
+	return t.session
+}
+
+func (t *Table[K, V]) GetUri() string {
+	return t.uri
+}
+
+// Get returns the value stored under key; ok is false if there is none.
+func (t *Table[K, V]) Get(key K) (value V, ok bool, err error) {
+	c := t.cursor
+	defer c.Reset()
+
+	if err = setTypedKey(c, key); err != nil {
+		return value, false, err
+	}
+
+	if err = c.Search(); err != nil {
+		if IsNotFoundErr(err) {
+			err = nil
+		}
+		return value, false, err
+	}
+
+	if err = getTypedValue(c, &value); err != nil {
+		return value, false, err
+	}
+
+	return value, true, nil
+}
+
+// Exists reports whether a value is stored under key.
+func (t *Table[K, V]) Exists(key K) (bool, error) {
+	c := t.cursor
+	defer c.Reset()
+
+	if err := setTypedKey(c, key); err != nil {
+		return false, err
+	}
+
+	if err := c.Search(); err != nil {
+		if IsNotFoundErr(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Put stores value under key, replacing any existing value.
+func (t *Table[K, V]) Put(key K, value V) error {
+	return t.write(t.cursor, key, value)
+}
+
+// Insert stores value under key, failing with WT_DUPLICATE_KEY if the key
+// already exists.
+func (t *Table[K, V]) Insert(key K, value V) error {
+	if t.insert == nil {
+		config := "overwrite=false"
+		if len(t.config) > 0 {
+			config = t.config + "," + config
+		}
+
+		c, err := t.session.OpenCursor(t.uri, nil, config)
+		if err != nil {
+			return err
+		}
+		t.insert = c
+	}
+
+	return t.write(t.insert, key, value)
+}
+
+func (t *Table[K, V]) write(c *Cursor, key K, value V) error {
+	if err := setTypedKey(c, key); err != nil {
+		return err
+	}
+
+	if err := setTypedValue(c, value); err != nil {
+		return err
+	}
+
+	return c.Insert()
+}
+
+// Delete removes key; removing a missing key is not an error.
+func (t *Table[K, V]) Delete(key K) error {
+	c := t.cursor
+	defer c.Reset()
+
+	if err := setTypedKey(c, key); err != nil {
+		return err
+	}
+
+	if err := c.Remove(); err != nil && !IsNotFoundErr(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Scan calls fn for each record in key order until fn returns false. The
+// scan has a cursor of its own, so fn may use the other methods of the
+// table.
+func (t *Table[K, V]) Scan(fn func(key K, value V) bool) error {
+	return t.ScanContext(context.Background(), fn)
+}
+
+// ScanContext is Scan, stopping with the context's error once ctx is done.
+func (t *Table[K, V]) ScanContext(ctx context.Context, fn func(key K, value V) bool) error {
+	c, err := t.session.OpenCursor(t.uri, nil, t.config)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	for {
+		var key K
+		var value V
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := c.Next(); err != nil {
+			if IsNotFoundErr(err) {
+				return nil
+			}
+			return err
+		}
+
+		if err := getTypedKey(c, &key); err != nil {
+			return err
+		}
+
+		if err := getTypedValue(c, &value); err != nil {
+			return err
+		}
+
+		if !fn(key, value) {
+			return nil
+		}
+	}
+}
+
+func isStruct(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t != nil && t.Kind() == reflect.Struct
+}
+
+func setTypedKey(c *Cursor, v interface{}) error {
+	if !isStruct(v) {
+		return c.SetKey(v)
+	}
+
+	var res error
+	c.keyPack, res = packStruct(c.session, c.keyFormat, c.keyPack, v, structAll)
+
+	c.keySetExt = res == nil
+	return res
+}
+
+func setTypedValue(c *Cursor, v interface{}) error {
+	if !isStruct(v) {
+		return c.SetValue(v)
+	}
+
+	var res error
+	c.valuePack, res = packStruct(c.session, c.valueFormat, c.valuePack, v, structAll)
+
+	c.valueSetExt = res == nil
+	return res
+}
+
+// getTypedKey and getTypedValue take a pointer to the destination.
+func getTypedKey(c *Cursor, v interface{}) error {
+	if !isStruct(v) {
+		return c.GetKey(v)
+	}
+
+	d, err := c.rawKey()
+	if err != nil {
+		return err
+	}
+
+	return unpackStruct(c.session, c.keyFormat, d, v, structAll)
+}
+
+func getTypedValue(c *Cursor, v interface{}) error {
+	if !isStruct(v) {
+		return c.GetValue(v)
+	}
+
+	d, err := c.rawValue()
+	if err != nil {
+		return err
+	}
+
+	return unpackStruct(c.session, c.valueFormat, d, v, structAll)
+}
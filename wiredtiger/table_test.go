@@ -0,0 +1,143 @@
+package wiredtiger
+
+import (
+	"errors"
+	"testing"
+)
+
+// openTestSession opens a new database in a temporary directory, closed when
+// the test ends.
+func openTestSession(t *testing.T, config string) (*Connection, *Session) {
+	t.Helper()
+
+	if len(config) > 0 {
+		config = "," + config
+	}
+
+	conn, err := Open(t.TempDir(), "create"+config)
+	if err != nil {
+		t.Fatalf("Got error while open database: %v", err)
+	}
+
+	t.Cleanup(func() { conn.Close("") })
+
+	session, err := conn.OpenSession("")
+	if err != nil {
+		t.Fatalf("Got error while open session: %v", err)
+	}
+
+	return conn, session
+}
+
+func TestTableScanUpdate(t *testing.T) {
+	_, session := openTestSession(t, "")
+
+	if err := session.Create("table:scan", "key_format=q,value_format=S"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	table, err := OpenTable[int64, string](session, "table:scan", "")
+	if err != nil {
+		t.Fatalf("Got error while open table: %v", err)
+	}
+	defer table.Close()
+
+	for i := int64(1); i <= 10; i++ {
+		if err = table.Put(i, "v"); err != nil {
+			t.Fatalf("Got error while put: %v", err)
+		}
+	}
+
+	seen := map[int64]int{}
+	visits := 0
+
+	err = table.Scan(func(key int64, value string) bool {
+		seen[key]++
+		visits++
+
+		// Read-then-update through the table's own cursor must not move the
+		// scan.
+		v, ok, err := table.Get(key)
+		if err != nil || !ok {
+			t.Errorf("Get(%d) returned %q, %v, %v", key, v, ok, err)
+			return false
+		}
+
+		if err = table.Put(key, v+"!"); err != nil {
+			t.Errorf("Put(%d) failed: %v", key, err)
+			return false
+		}
+
+		// A scan that restarts would never end.
+		return visits < 100
+	})
+	if err != nil {
+		t.Fatalf("Got error while scan: %v", err)
+	}
+
+	if len(seen) != 10 {
+		t.Errorf("Expected 10 records visited, got %d", len(seen))
+	}
+
+	for key, n := range seen {
+		if n != 1 {
+			t.Errorf("Record %d visited %d times", key, n)
+		}
+
+		if v, _, _ := table.Get(key); v != "v!" {
+			t.Errorf("Record %d holds %q, expected %q", key, v, "v!")
+		}
+	}
+}
+
+func TestTableInsertDelete(t *testing.T) {
+	_, session := openTestSession(t, "")
+
+	if err := session.Create("table:insert", "key_format=S,value_format=S"); err != nil {
+		t.Fatalf("Got error while create table: %v", err)
+	}
+
+	table, err := OpenTable[string, string](session, "table:insert", "")
+	if err != nil {
+		t.Fatalf("Got error while open table: %v", err)
+	}
+	defer table.Close()
+
+	if err = table.Insert("a", "1"); err != nil {
+		t.Fatalf("Got error while insert: %v", err)
+	}
+
+	var e *Error
+	if err = table.Insert("a", "2"); !errors.As(err, &e) || e.Code != WT_DUPLICATE_KEY {
+		t.Errorf("Insert of an existing key returned %v, expected WT_DUPLICATE_KEY", err)
+	}
+
+	if v, ok, err := table.Get("a"); err != nil || !ok || v != "1" {
+		t.Errorf("Get returned %q, %v, %v, expected the first value", v, ok, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err = table.Delete("a"); err != nil {
+			t.Errorf("Delete %d returned %v", i+1, err)
+		}
+	}
+
+	if ok, err := table.Exists("a"); err != nil || ok {
+		t.Errorf("Exists after Delete returned %v, %v", ok, err)
+	}
+
+	// Insert opens its cursor with the table's configuration too.
+	readonly, err := OpenTable[string, string](session, "table:insert", "readonly=true")
+	if err != nil {
+		t.Fatalf("Got error while open table: %v", err)
+	}
+	defer readonly.Close()
+
+	if err = readonly.Put("b", "1"); err == nil {
+		t.Error("Expected an error while put through a readonly table")
+	}
+
+	if err = readonly.Insert("b", "1"); err == nil {
+		t.Error("Expected an error while insert through a readonly table")
+	}
+}
@@ -0,0 +1,223 @@
+package wiredtiger
+
+/*
+#cgo LDFLAGS: -lwiredtiger
+#include <stdlib.h>
+#include <wiredtiger.h>
+
+static int wiredtiger_connection_set_timestamp(WT_CONNECTION *connection, const char *config) {
+	return connection->set_timestamp(connection, config);
+}
+
+static int wiredtiger_connection_query_timestamp(WT_CONNECTION *connection, char *hex_timestamp, const char *config) {
+	return connection->query_timestamp(connection, hex_timestamp, config);
+}
+
+static int wiredtiger_session_prepare_transaction(WT_SESSION *session, const char *config) {
+	return session->prepare_transaction(session, config);
+}
+
+static int wiredtiger_session_timestamp_transaction(WT_SESSION *session, const char *config) {
+	return session->timestamp_transaction(session, config);
+}
+
+static int wiredtiger_session_query_timestamp(WT_SESSION *session, char *hex_timestamp, const char *config) {
+	return session->query_timestamp(session, hex_timestamp, config);
+}
+*/
+import "C"
+import (
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Timestamp is an application-assigned point in time for multi-version
+// concurrency control. WiredTiger exchanges timestamps as hexadecimal
+// strings; zero means no timestamp.
+type Timestamp uint64
+
+// String returns the hexadecimal form WiredTiger configuration expects.
+func (t Timestamp) String() string {
+	return strconv.FormatUint(uint64(t), 16)
+}
+
+// ParseTimestamp parses the hexadecimal form of a timestamp.
+func ParseTimestamp(hex string) (Timestamp, error) {
+	v, err := strconv.ParseUint(hex, 16, 64)
+	if err != nil {
+		return 0, NewError(EINVAL, nil)
+	}
+
+	return Timestamp(v), nil
+}
+
+type TimestampKind string
+
+// Connection timestamps, for Connection.QueryTimestamp.
+const (
+	TimestampAllDurable   TimestampKind = "all_durable"
+	TimestampOldest       TimestampKind = "oldest"
+	TimestampOldestReader TimestampKind = "oldest_reader"
+	TimestampPinned       TimestampKind = "pinned"
+	TimestampStable       TimestampKind = "stable"
+)
+
+// Transaction timestamps, for Session.QueryTimestamp.
+const (
+	TimestampCommit      TimestampKind = "commit"
+	TimestampFirstCommit TimestampKind = "first_commit"
+	TimestampPrepare     TimestampKind = "prepare"
+	TimestampRead        TimestampKind = "read"
+)
+
+// GlobalTimestamps are the connection-wide timestamps set by
+// Connection.SetTimestamp; zero fields are left unchanged.
+type GlobalTimestamps struct {
+	Oldest  Timestamp
+	Stable  Timestamp
+	Durable Timestamp
+	// Force allows moving the timestamps backwards.
+	Force bool
+}
+
+// TxnTimestamps are the timestamps of a running transaction set by
+// Session.TimestampTransaction; zero fields are left unchanged.
+type TxnTimestamps struct {
+	Commit  Timestamp
+	Durable Timestamp
+	Read    Timestamp
+}
+
+type timestampConfig []string
+
+func (c *timestampConfig) add(key string, ts Timestamp) {
+	if ts != 0 {
+		*c = append(*c, key+"="+ts.String())
+	}
+}
+
+func (c timestampConfig) String() string {
+	return strings.Join(c, ",")
+}
+
+func (c *Connection) SetTimestamp(ts GlobalTimestamps) error {
+	var config timestampConfig
+
+	config.add("oldest_timestamp", ts.Oldest)
+	config.add("stable_timestamp", ts.Stable)
+	config.add("durable_timestamp", ts.Durable)
+
+	if len(config) == 0 {
+		return nil
+	}
+
+	if ts.Force {
+		config = append(config, "force=true")
+	}
+
+	configC := C.CString(config.String())
+	defer C.free(unsafe.Pointer(configC))
+
+	if res := int(C.wiredtiger_connection_set_timestamp(c.w, configC)); res != 0 {
+		return NewError(res, nil)
+	}
+
+	return nil
+}
+
+// Room for a 64-bit timestamp in hexadecimal, with some to spare for
+// engines with wider ones.
+const timestampHexSize = 64
+
+func (c *Connection) QueryTimestamp(kind TimestampKind) (Timestamp, error) {
+	var hex [timestampHexSize]C.char
+
+	configC := C.CString("get=" + string(kind))
+	defer C.free(unsafe.Pointer(configC))
+
+	if res := int(C.wiredtiger_connection_query_timestamp(c.w, &hex[0], configC)); res != 0 {
+		return 0, NewError(res, nil)
+	}
+
+	return ParseTimestamp(C.GoString(&hex[0]))
+}
+
+func (s *Session) QueryTimestamp(kind TimestampKind) (Timestamp, error) {
+	var hex [timestampHexSize]C.char
+
+	configC := C.CString("get=" + string(kind))
+	defer C.free(unsafe.Pointer(configC))
+
+	if res := int(C.wiredtiger_session_query_timestamp(s.w, &hex[0], configC)); res != 0 {
+		return 0, NewError(res, s)
+	}
+
+	return ParseTimestamp(C.GoString(&hex[0]))
+}
+
+// PrepareTransaction prepares the running transaction for a two-phase
+// commit at prepare. Once prepared it can only be committed, with a commit
+// timestamp no earlier than prepare, or rolled back.
+func (s *Session) PrepareTransaction(prepare Timestamp) error {
+	if prepare == 0 {
+		return NewError(EINVAL, s)
+	}
+
+	configC := C.CString("prepare_timestamp=" + prepare.String())
+	defer C.free(unsafe.Pointer(configC))
+
+	if res := int(C.wiredtiger_session_prepare_transaction(s.w, configC)); res != 0 {
+		return NewError(res, s)
+	}
+
+	return nil
+}
+
+// TimestampTransaction sets timestamps of the running transaction, such as
+// a commit timestamp for its following updates.
+func (s *Session) TimestampTransaction(ts TxnTimestamps) error {
+	var config timestampConfig
+
+	config.add("commit_timestamp", ts.Commit)
+	config.add("durable_timestamp", ts.Durable)
+	config.add("read_timestamp", ts.Read)
+
+	if len(config) == 0 {
+		return nil
+	}
+
+	configC := C.CString(config.String())
+	defer C.free(unsafe.Pointer(configC))
+
+	if res := int(C.wiredtiger_session_timestamp_transaction(s.w, configC)); res != 0 {
+		return NewError(res, s)
+	}
+
+	return nil
+}
+
+// BeginTransactionWith is BeginTransaction configured by opts, which may be
+// nil.
+func (s *Session) BeginTransactionWith(opts *TxnOptions) error {
+	if opts == nil {
+		opts = &TxnOptions{}
+	}
+
+	config, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	return s.BeginTransaction(config)
+}
+
+// CommitTransactionWith is CommitTransaction with the commit and durable
+// timestamps of opts, which may be nil.
+func (s *Session) CommitTransactionWith(opts *TxnOptions) error {
+	if opts == nil {
+		opts = &TxnOptions{}
+	}
+
+	return s.CommitTransaction(opts.commitConfig())
+}
@@ -0,0 +1,276 @@
+package wiredtiger
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type Isolation int
+
+const (
+	// IsolationDefault uses the session's isolation level.
+	IsolationDefault Isolation = iota
+	ReadUncommitted
+	ReadCommitted
+	Snapshot
+)
+
+func (i Isolation) String() string {
+	switch i {
+	case ReadUncommitted:
+		return "read-uncommitted"
+	case ReadCommitted:
+		return "read-committed"
+	case Snapshot:
+		return "snapshot"
+	}
+
+	return ""
+}
+
+type TxnSync int
+
+const (
+	// SyncDefault follows the connection's transaction_sync setting.
+	SyncDefault TxnSync = iota
+	SyncOn
+	SyncOff
+)
+
+// TxnOptions configures a transaction begun by Session.WithTransaction. The
+// zero value begins a transaction with the session defaults and retries
+// rollbacks up to 10 times.
+type TxnOptions struct {
+	Isolation Isolation
+	Sync      TxnSync
+	Name      string
+	// Priority, from -100 to 100, makes WiredTiger prefer rolling back
+	// lower priority transactions when evicting under pressure.
+	Priority int
+
+	// ReadTimestamp reads as of that time; CommitTimestamp and
+	// DurableTimestamp are given to the commit. Zero leaves them unset.
+	ReadTimestamp    Timestamp
+	CommitTimestamp  Timestamp
+	DurableTimestamp Timestamp
+
+	// MaxRetries bounds the retries after WT_ROLLBACK; 10 when zero,
+	// none when negative.
+	MaxRetries int
+	// MinBackoff and MaxBackoff bound the jittered exponential delay
+	// before a retry; 1ms and 100ms when zero.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Config renders the options as a WT_SESSION::begin_transaction
+// configuration; the timestamps for the commit are rendered separately.
+func (o *TxnOptions) Config() (string, error) {
+	var config []string
+
+	switch o.Isolation {
+	case IsolationDefault:
+	case ReadUncommitted, ReadCommitted, Snapshot:
+		config = append(config, "isolation="+o.Isolation.String())
+	default:
+		return "", NewError(EINVAL, nil)
+	}
+
+	switch o.Sync {
+	case SyncDefault:
+	case SyncOn:
+		config = append(config, "sync=true")
+	case SyncOff:
+		config = append(config, "sync=false")
+	default:
+		return "", NewError(EINVAL, nil)
+	}
+
+	if len(o.Name) > 0 {
+		config = append(config, "name="+strconv.Quote(o.Name))
+	}
+
+	if o.Priority < -100 || o.Priority > 100 {
+		return "", NewError(EINVAL, nil)
+	} else if o.Priority != 0 {
+		config = append(config, "priority="+strconv.Itoa(o.Priority))
+	}
+
+	if o.ReadTimestamp != 0 {
+		config = append(config, "read_timestamp="+o.ReadTimestamp.String())
+	}
+
+	return strings.Join(config, ","), nil
+}
+
+func (o *TxnOptions) commitConfig() string {
+	var config timestampConfig
+
+	config.add("commit_timestamp", o.CommitTimestamp)
+	config.add("durable_timestamp", o.DurableTimestamp)
+
+	return config.String()
+}
+
+// backoff returns the delay before retry attempt n, counting from 1.
+func (o *TxnOptions) backoff(n int) time.Duration {
+	lo, hi := o.MinBackoff, o.MaxBackoff
+	if lo <= 0 {
+		lo = time.Millisecond
+	}
+	if hi <= 0 {
+		hi = 100 * time.Millisecond
+	}
+
+	d := hi
+	if n < 32 && lo<<(n-1) < hi {
+		d = lo << (n - 1)
+	}
+
+	// Full jitter over the upper half keeps contending retries apart.
+	return d/2 + rand.N(d/2+1)
+}
+
+// Txn is the transaction passed to the function run by WithTransaction.
+type Txn struct {
+	session *Session
+	// Attempt counts the runs of the function, from 1.
+	Attempt int
+}
+
+func (tx *Txn) Session() *Session {
+	return tx.session
+}
+
+// TxnMetrics counts the transactions run by WithTransaction on a
+// connection.
+type TxnMetrics struct {
+	Committed  uint64
+	RolledBack uint64
+	// Retries counts the reruns after WT_ROLLBACK, and Exhausted the
+	// transactions that gave up still rolling back.
+	Retries   uint64
+	Exhausted uint64
+}
+
+type txnCounters struct {
+	committed  atomic.Uint64
+	rolledBack atomic.Uint64
+	retries    atomic.Uint64
+	exhausted  atomic.Uint64
+}
+
+func (c *Connection) TxnMetrics() TxnMetrics {
+	return TxnMetrics{
+		Committed:  c.txnStats.committed.Load(),
+		RolledBack: c.txnStats.rolledBack.Load(),
+		Retries:    c.txnStats.retries.Load(),
+		Exhausted:  c.txnStats.exhausted.Load(),
+	}
+}
+
+func isRollback(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Code == WT_ROLLBACK
+}
+
+// WithTransaction runs fn in a transaction, committing if it returns nil
+// and rolling back if it returns an error or panics. When fn or the commit
+// fails with WT_ROLLBACK, typically a write conflict, the transaction is
+// run again after a backoff, so fn must be safe to repeat. ctx bounds the
+// retries: its error is returned once it is done, including for a rollback
+// that happens after that. On WiredTiger 10 and later its deadline is also
+// given to each attempt as operation_timeout_ms. opts may be nil.
+func (s *Session) WithTransaction(ctx context.Context, opts *TxnOptions, fn func(tx *Txn) error) error {
+	if opts == nil {
+		opts = &TxnOptions{}
+	}
+
+	config, err := opts.Config()
+	if err != nil {
+		return err
+	}
+
+	commitConfig := opts.commitConfig()
+
+	retries := opts.MaxRetries
+	if retries == 0 {
+		retries = 10
+	}
+
+	stats := &s.conn.txnStats
+
+	for attempt := 1; ; attempt++ {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		begin := config
+		if operationTimeout() {
+			if begin, err = deadlineConfig(ctx, config, "operation_timeout_ms", time.Millisecond); err != nil {
+				return err
+			}
+		}
+
+		if err = s.runTransaction(begin, commitConfig, &Txn{session: s, Attempt: attempt}, fn); !isRollback(err) {
+			return err
+		}
+
+		// An operation timeout rolls the transaction back.
+		if cerr := ctx.Err(); cerr != nil {
+			return cerr
+		}
+
+		if attempt > retries {
+			stats.exhausted.Add(1)
+			return err
+		}
+
+		stats.retries.Add(1)
+
+		t := time.NewTimer(opts.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (s *Session) runTransaction(config, commitConfig string, tx *Txn, fn func(tx *Txn) error) (err error) {
+	stats := &s.conn.txnStats
+
+	if err = s.BeginTransaction(config); err != nil {
+		return err
+	}
+
+	committed := false
+
+	defer func() {
+		if !committed {
+			s.RollbackTransaction("")
+			stats.rolledBack.Add(1)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	// A failed commit rolls the transaction back by itself.
+	committed = true
+
+	if err = s.CommitTransaction(commitConfig); err != nil {
+		stats.rolledBack.Add(1)
+		return err
+	}
+
+	stats.committed.Add(1)
+	return nil
+}
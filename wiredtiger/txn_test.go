@@ -0,0 +1,81 @@
+package wiredtiger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTxnOptionsConfig(t *testing.T) {
+	opts := &TxnOptions{Isolation: Snapshot, Sync: SyncOff, Name: "load", Priority: -5, ReadTimestamp: 0x1a, CommitTimestamp: 0x2b}
+
+	config, err := opts.Config()
+	if err != nil {
+		t.Fatalf("Got error while rendering config: %v", err)
+	}
+
+	expected := `isolation=snapshot,sync=false,name="load",priority=-5,read_timestamp=1a`
+	if config != expected {
+		t.Errorf("Unexpected config %q, expected %q", config, expected)
+	}
+
+	if config = opts.commitConfig(); config != "commit_timestamp=2b" {
+		t.Errorf("Unexpected commit config %q", config)
+	}
+
+	if config, _ = (&TxnOptions{}).Config(); config != "" {
+		t.Errorf("Expected empty config for the zero value, got %q", config)
+	}
+
+	if _, err = (&TxnOptions{Priority: 101}).Config(); err == nil {
+		t.Error("Expected an error for an out of range priority")
+	}
+}
+
+func TestTxnBackoff(t *testing.T) {
+	opts := &TxnOptions{MinBackoff: 2 * time.Millisecond, MaxBackoff: 20 * time.Millisecond}
+
+	for n, ceiling := range map[int]time.Duration{1: 2, 2: 4, 3: 8, 4: 16, 5: 20, 40: 20} {
+		ceiling *= time.Millisecond
+
+		for i := 0; i < 100; i++ {
+			if d := opts.backoff(n); d < ceiling/2 || d > ceiling {
+				t.Fatalf("Backoff %v for attempt %d outside [%v, %v]", d, n, ceiling/2, ceiling)
+			}
+		}
+	}
+}
+
+func TestTimestampHex(t *testing.T) {
+	ts, err := ParseTimestamp(Timestamp(0xdeadbeef01).String())
+	if err != nil || ts != 0xdeadbeef01 {
+		t.Errorf("Timestamp round trip returned %v, %v", ts, err)
+	}
+
+	if _, err = ParseTimestamp("0x10"); err == nil {
+		t.Error("Expected an error for a prefixed timestamp")
+	}
+}
+
+func TestWithTransactionDeadline(t *testing.T) {
+	_, session := openTestSession(t, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	err := session.WithTransaction(ctx, nil, func(tx *Txn) error {
+		attempts++
+		<-ctx.Done()
+		return &Error{Code: WT_ROLLBACK}
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Got %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	if attempts != 1 {
+		t.Errorf("Got %d attempts, expected 1", attempts)
+	}
+}
@@ -11,22 +11,34 @@ import "C"
 import "unsafe"
 
 func Open(home, config string) (*Connection, error) {
+	return OpenWithHandler(home, nil, config)
+}
+
+// OpenWithHandler opens a connection whose error, message and progress
+// events are delivered to handler. Sessions opened without a handler of
+// their own inherit it.
+func OpenWithHandler(home string, handler EventHandler, config string) (*Connection, error) {
 	var w *C.WT_CONNECTION
 
 	homeC := C.CString(home)
 	configC := C.CString(config)
 
-	result := int(C.wiredtiger_open(homeC, nil, configC, &w))
+	h := newEventHandler(handler)
+
+	beginOpen()
+	result := int(C.wiredtiger_open(homeC, h.iface(), configC, &w))
 
 	C.free(unsafe.Pointer(homeC))
 	C.free(unsafe.Pointer(configC))
 
 	if result == 0 {
-		conn := new(Connection)
-		conn.w = w
+		conn := endOpen(w)
+		conn.handler = h
 		return conn, nil
 	}
 
+	abandonOpen(w)
+	h.free()
 	return nil, NewError(result, nil)
 }
 
@@ -41,6 +53,16 @@ func Version() (vertxt string, major int, minor int, patch int) {
 	return
 }
 
+// borrowBytes views C memory as a byte slice without copying it. The slice
+// is only valid for as long as WiredTiger keeps the memory alive.
+func borrowBytes(data unsafe.Pointer, size C.size_t) []byte {
+	if data == nil || size == 0 {
+		return nil
+	}
+
+	return unsafe.Slice((*byte)(data), int(size))
+}
+
 func strerror(r int) string {
 	return C.GoString(C.wiredtiger_strerror(C.int(r)))
 }